@@ -18,13 +18,17 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"strconv"
 	"time"
 
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/metrics"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/outputs"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/credentials"
 )
 
 var log = logf.Log.WithName("apiserver")
@@ -32,21 +36,35 @@ var log = logf.Log.WithName("apiserver")
 type Options struct {
 	Address string
 	Port    int
+	// Outputs collects the outputs the runs post to the /outputs endpoint,
+	// which is not served if nil.
+	Outputs *outputs.Tracker
+	// Credentials are the tokens issued to the runs. A run posts its outputs
+	// with its own token.
+	Credentials *credentials.Store
 }
 
 func Run(options Options) error {
-	mux := http.NewServeMux()
-	mux.HandleFunc("/metrics", metricsHandler)
-
 	server := http.Server{
-		Addr:              fmt.Sprintf("%s:%d", options.Address, options.Port),
-		Handler:           mux,
+		Addr:              net.JoinHostPort(options.Address, strconv.Itoa(options.Port)),
+		Handler:           newMux(options),
 		ReadHeaderTimeout: 5 * time.Second,
 	}
 	log.Info("Starting to serve metrics listener", "Address", server.Addr)
 	return server.ListenAndServe()
 }
 
+// newMux routes the requests to the endpoints enabled by options.
+func newMux(options Options) *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/metrics", metricsHandler)
+	if options.Outputs != nil {
+		h := outputsHandler{outputs: options.Outputs, credentials: options.Credentials}
+		mux.HandleFunc("POST /outputs/{ident}", h.set)
+	}
+	return mux
+}
+
 func metricsHandler(w http.ResponseWriter, r *http.Request) {
 	defer func() {
 		_, _ = io.Copy(io.Discard, r.Body)
@@ -70,6 +88,19 @@ func metricsHandler(w http.ResponseWriter, r *http.Request) {
 			log.Info(err.Error())
 			http.Error(w, err.Error(), http.StatusBadRequest)
 		}
+	case http.MethodDelete:
+		log.V(3).Info("The apiserver has received a DELETE")
+		err := json.NewDecoder(r.Body).Decode(&userMetric)
+		if err != nil {
+			log.Info(err.Error())
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		err = metrics.DeleteUserMetric(crmetrics.Registry, userMetric)
+		if err != nil {
+			log.Info(err.Error())
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
 	default:
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 	}
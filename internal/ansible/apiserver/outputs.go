@@ -0,0 +1,64 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/outputs"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/credentials"
+)
+
+// maxOutputsSize bounds the size of the outputs posted at once.
+const maxOutputsSize = 1 << 20
+
+// outputsHandler - collects the outputs the runs post.
+type outputsHandler struct {
+	outputs     *outputs.Tracker
+	credentials *credentials.Store
+}
+
+// set - sets the outputs of a run, posted as a JSON object with the token
+// issued to the run.
+func (h outputsHandler) set(w http.ResponseWriter, r *http.Request) {
+	ident := r.PathValue("ident")
+	token, _ := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	c, ok := h.credentials.Lookup(token)
+	if !ok {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+	// a run may only set its own outputs.
+	if c.Ident != ident {
+		log.Info("Rejecting outputs posted with the token of another run", "ident", ident)
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	set := map[string]interface{}{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxOutputsSize)).Decode(&set); err != nil {
+		log.Info(err.Error())
+		http.Error(w, "outputs must be a JSON object: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !h.outputs.Set(ident, set) {
+		http.Error(w, "the outputs of the run are not kept", http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,121 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/outputs"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/credentials"
+)
+
+func TestOutputsEndpoint(t *testing.T) {
+	tracker := outputs.NewTracker()
+	tracker.Start("1234")
+	tracker.Start("4321")
+	store := credentials.NewStore(time.Hour)
+	token, err := store.Issue(credentials.Credential{Ident: "1234"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherToken, err := store.Issue(credentials.Credential{Ident: "4321"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the token of the /runs endpoints is not accepted.
+	ts := httptest.NewServer(newMux(Options{Outputs: tracker, Credentials: store}))
+	defer ts.Close()
+
+	for _, tc := range []struct {
+		ident    string
+		token    string
+		body     string
+		expected int
+	}{
+		{ident: "1234", token: token, body: `{"revision": "v2", "stale": null}`, expected: http.StatusNoContent},
+		{ident: "1234", token: token, body: `["revision"]`, expected: http.StatusBadRequest},
+		{ident: "1234", body: `{"revision": "v3"}`, expected: http.StatusUnauthorized},
+		{ident: "1234", token: "secret", body: `{"revision": "v3"}`, expected: http.StatusUnauthorized},
+		{ident: "1234", token: otherToken, body: `{"revision": "v3"}`, expected: http.StatusForbidden},
+		{ident: "4321", token: token, body: `{"revision": "v3"}`, expected: http.StatusForbidden},
+		{ident: "5678", token: token, body: `{"revision": "v3"}`, expected: http.StatusForbidden},
+	} {
+		req, err := http.NewRequest(http.MethodPost, ts.URL+"/outputs/"+tc.ident, strings.NewReader(tc.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if tc.token != "" {
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tc.expected {
+			t.Fatalf("Unexpected status %d posting %s to run %s", resp.StatusCode, tc.body, tc.ident)
+		}
+	}
+
+	expected := map[string]interface{}{"revision": "v2", "stale": nil}
+	if got := tracker.Stop("1234"); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Unexpected outputs\nexpected: %v\nactual: %v", expected, got)
+	}
+	if got := tracker.Stop("4321"); len(got) != 0 {
+		t.Fatalf("Unexpected outputs of another run: %v", got)
+	}
+}
+
+func TestOutputsEndpointNotKept(t *testing.T) {
+	store := credentials.NewStore(time.Hour)
+	token, err := store.Issue(credentials.Credential{Ident: "1234"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(newMux(Options{Outputs: outputs.NewTracker(), Credentials: store}))
+	defer ts.Close()
+	req, err := http.NewRequest(http.MethodPost, ts.URL+"/outputs/1234", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("Unexpected status %d", resp.StatusCode)
+	}
+}
+
+func TestOutputsEndpointDisabled(t *testing.T) {
+	ts := httptest.NewServer(newMux(Options{}))
+	defer ts.Close()
+	resp, err := http.Post(ts.URL+"/outputs/1234", "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("Unexpected status %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runs"
+)
+
+// RunsOptions - the options of the listener serving the read-only /runs
+// endpoints introspecting the runs. They are served apart from the apiserver,
+// which the runs post their metrics and outputs to at localhost:5050, so that
+// they can be exposed without moving it.
+type RunsOptions struct {
+	// Address is the host:port the endpoints are served on.
+	Address string
+	Runs    *runs.Tracker
+	// Token is the bearer token required by the endpoints, if set.
+	Token string
+}
+
+// Validate - returns an error if the options are invalid. The endpoints are
+// only served without a token on a loopback address, since the events and
+// stdout of the runs are otherwise exposed to anyone reaching the address.
+func (o RunsOptions) Validate() error {
+	host, _, err := net.SplitHostPort(o.Address)
+	if err != nil {
+		return fmt.Errorf("invalid runs bind address: %w", err)
+	}
+	if o.Token != "" {
+		return nil
+	}
+	if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+		return fmt.Errorf("a token is required to serve the /runs endpoints on the non-loopback address %s",
+			o.Address)
+	}
+	return nil
+}
+
+// ServeRuns - serves the /runs endpoints until the listener fails.
+func ServeRuns(options RunsOptions) error {
+	if err := options.Validate(); err != nil {
+		return err
+	}
+	server := http.Server{
+		Addr:              options.Address,
+		Handler:           newRunsMux(options),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	log.Info("Starting to serve runs listener", "Address", server.Addr)
+	return server.ListenAndServe()
+}
+
+// newRunsMux routes the requests to the /runs endpoints.
+func newRunsMux(options RunsOptions) *http.ServeMux {
+	mux := http.NewServeMux()
+	h := runsHandler{runs: options.Runs}
+	mux.Handle("GET /runs", requireToken(options.Token, http.HandlerFunc(h.list)))
+	mux.Handle("GET /runs/{ident}/events", requireToken(options.Token, http.HandlerFunc(h.events)))
+	mux.Handle("GET /runs/{ident}/stdout", requireToken(options.Token, http.HandlerFunc(h.stdout)))
+	return mux
+}
+
+// requireToken - rejects the requests without the bearer token, if set.
+func requireToken(token string, h http.Handler) http.Handler {
+	if token == "" {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// runsHandler - serves the read-only endpoints introspecting the runs.
+type runsHandler struct {
+	runs *runs.Tracker
+}
+
+// list - lists the runs in flight and the recent ones.
+func (h runsHandler) list(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(h.runs.List()); err != nil {
+		log.Error(err, "Failed to write the runs")
+	}
+}
+
+// stdout - writes the stdout of a run so far.
+func (h runsHandler) stdout(w http.ResponseWriter, r *http.Request) {
+	run, ok := h.runs.Get(r.PathValue("ident"))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, _ = io.WriteString(w, run.Stdout())
+}
+
+// events - streams the events of a run as Server-Sent Events, from its first
+// event until it finishes or the client goes away.
+func (h runsHandler) events(w http.ResponseWriter, r *http.Request) {
+	run, ok := h.runs.Get(r.PathValue("ident"))
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	sent := 0
+	for {
+		events, finished, changed := run.EventsSince(sent)
+		for _, e := range events {
+			sent++
+			data, err := json.Marshal(e)
+			if err != nil {
+				log.Error(err, "Failed to encode event", "job", r.PathValue("ident"))
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", sent, e.Event, data); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+		if finished && len(events) == 0 {
+			return
+		}
+		select {
+		case <-changed:
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
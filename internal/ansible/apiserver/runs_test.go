@@ -0,0 +1,150 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiserver
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runs"
+)
+
+func get(t *testing.T, url, token string) *http.Response {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestRunsEndpoints(t *testing.T) {
+	tracker := runs.NewTracker(runs.DefaultKeep)
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("cache.example.com/v1alpha1")
+	u.SetKind("Memcached")
+	u.SetName("example")
+	run := tracker.Start("1234", u)
+	run.Event(eventapi.JobEvent{Event: eventapi.EventPlaybookOnTaskStart, StdOut: "TASK [probe]",
+		EventData: map[string]interface{}{"task": "probe"}})
+
+	ts := httptest.NewServer(newRunsMux(RunsOptions{Runs: tracker, Token: "secret"}))
+	defer ts.Close()
+
+	for _, token := range []string{"", "wrong"} {
+		resp := get(t, ts.URL+"/runs", token)
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("Unexpected status %d with token %q", resp.StatusCode, token)
+		}
+	}
+
+	resp := get(t, ts.URL+"/runs", "secret")
+	list := []runs.Info{}
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if len(list) != 1 || list[0].Ident != "1234" || !list[0].Running || list[0].CurrentTask != "probe" {
+		t.Fatalf("Unexpected runs %+v", list)
+	}
+
+	resp = get(t, ts.URL+"/runs/4321/stdout", "secret")
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("Unexpected status %d for an unknown run", resp.StatusCode)
+	}
+
+	// the events already received are replayed, and the stream follows the
+	// run until it finishes.
+	resp = get(t, ts.URL+"/runs/1234/events", "secret")
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("Unexpected content type %q", ct)
+	}
+	reader := bufio.NewReader(resp.Body)
+	readEvent := func() []string {
+		var lines []string
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				t.Fatalf("Unexpected error reading the stream: %v", err)
+			}
+			if line == "\n" {
+				return lines
+			}
+			lines = append(lines, strings.TrimSuffix(line, "\n"))
+		}
+	}
+	if lines := readEvent(); len(lines) != 3 || lines[0] != "id: 1" || lines[1] != "event: playbook_on_task_start" {
+		t.Fatalf("Unexpected first event %v", lines)
+	}
+	run.Event(eventapi.JobEvent{Event: eventapi.EventRunnerOnOk, StdOut: "ok: [localhost]"})
+	if lines := readEvent(); len(lines) != 3 || lines[0] != "id: 2" || lines[1] != "event: runner_on_ok" {
+		t.Fatalf("Unexpected second event %v", lines)
+	}
+	run.Finish(runner.Outcome{Status: runner.RunSuccessful})
+	if rest, err := io.ReadAll(reader); err != nil || len(rest) != 0 {
+		t.Fatalf("Expected the stream to end with the run, got %q, %v", rest, err)
+	}
+
+	resp = get(t, ts.URL+"/runs/1234/stdout", "secret")
+	stdout, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(stdout) != "TASK [probe]\nok: [localhost]\n" {
+		t.Fatalf("Unexpected stdout %q", stdout)
+	}
+}
+
+func TestRunsOptionsValidate(t *testing.T) {
+	testCases := []struct {
+		address     string
+		token       string
+		shouldError bool
+	}{
+		{address: "localhost:5051"},
+		{address: "127.0.0.1:5051"},
+		{address: "[::1]:5051"},
+		{address: "0.0.0.0:5051", shouldError: true},
+		{address: ":5051", shouldError: true},
+		{address: "10.0.0.1:5051", shouldError: true},
+		{address: ":5051", token: "secret"},
+		{address: "localhost", shouldError: true},
+	}
+	for _, tc := range testCases {
+		err := RunsOptions{Address: tc.address, Token: tc.token}.Validate()
+		if tc.shouldError && err == nil {
+			t.Fatalf("Expected an error for address %q and token %q", tc.address, tc.token)
+		}
+		if !tc.shouldError && err != nil {
+			t.Fatalf("Unexpected error for address %q and token %q: %v", tc.address, tc.token, err)
+		}
+	}
+}
@@ -22,10 +22,12 @@ import (
 	"time"
 
 	libpredicate "github.com/operator-framework/operator-lib/predicate"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -35,7 +37,15 @@ import (
 
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/events"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/handler"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/impersonation"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/outputs"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/paramconv"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/controllermap"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/credentials"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/inventory"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/writes"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runs"
 )
 
 var log = logf.Log.WithName("ansible-controller")
@@ -43,7 +53,9 @@ var log = logf.Log.WithName("ansible-controller")
 // Options - options for your controller
 type Options struct {
 	EventHandlers               []events.EventHandler
+	EventDispatch               events.DispatchOptions
 	LoggingLevel                events.LogLevel
+	LogFormat                   events.LogFormat
 	Runner                      runner.Runner
 	GVK                         schema.GroupVersionKind
 	ReconcilePeriod             time.Duration
@@ -54,6 +66,17 @@ type Options struct {
 	WatchAnnotationsChanges     bool
 	MaxConcurrentReconciles     int
 	Selector                    metav1.LabelSelector
+	OutputsStore                string
+	Outputs                     *outputs.Tracker
+	StatusConverter             *paramconv.Converter
+	Runs                        *runs.Tracker
+	Credentials                 *credentials.Store
+	ProxyURL                    string
+	Impersonate                 *impersonation.ServiceAccount
+	Inventory                   *inventory.Recorder
+	PruneKinds                  []schema.GroupKind
+	ControllerMap               *controllermap.ControllerMap
+	Writes                      *writes.Tracker
 }
 
 // Add - Creates a new ansible operator controller and adds it to the manager
@@ -63,18 +86,36 @@ func Add(mgr manager.Manager, options Options) *controller.Controller {
 	if options.EventHandlers == nil {
 		options.EventHandlers = []events.EventHandler{}
 	}
-	eventHandlers := append(options.EventHandlers, events.NewLoggingEventHandler(options.LoggingLevel))
+	// the handlers may be shared between controllers, so they are copied
+	// before adding the logging handler.
+	eventHandlers := append(append([]events.EventHandler{}, options.EventHandlers...),
+		events.NewLoggingEventHandler(options.LoggingLevel, options.LogFormat))
 
 	aor := &AnsibleOperatorReconciler{
 		Client:                  mgr.GetClient(),
 		GVK:                     options.GVK,
 		Runner:                  options.Runner,
 		EventHandlers:           eventHandlers,
+		EventDispatch:           options.EventDispatch,
+		LogFormat:               options.LogFormat,
+		Runs:                    options.Runs,
+		Credentials:             options.Credentials,
+		ProxyURL:                options.ProxyURL,
+		Impersonate:             options.Impersonate,
+		ImpersonatedClient:      impersonatedClient(mgr),
+		Inventory:               options.Inventory,
+		PruneKinds:              options.PruneKinds,
+		ControllerMap:           options.ControllerMap,
+		Writes:                  options.Writes,
 		ReconcilePeriod:         options.ReconcilePeriod,
 		ManageStatus:            options.ManageStatus,
 		AnsibleDebugLogs:        options.AnsibleDebugLogs,
 		APIReader:               mgr.GetAPIReader(),
 		WatchAnnotationsChanges: options.WatchAnnotationsChanges,
+		OutputsStore:            options.OutputsStore,
+		Outputs:                 options.Outputs,
+		StatusConverter:         options.StatusConverter,
+		eventTimes:              newEventTimes(),
 	}
 
 	scheme := mgr.GetScheme()
@@ -91,6 +132,21 @@ func Add(mgr manager.Manager, options Options) *controller.Controller {
 		os.Exit(1)
 	}
 
+	// The inventory of the objects to prune is kept in a ConfigMap in the
+	// namespace of the resource.
+	if len(options.PruneKinds) > 0 {
+		mapping, err := mgr.GetRESTMapper().RESTMapping(options.GVK.GroupKind(), options.GVK.Version)
+		if err != nil {
+			log.Error(err, "Unable to get the scope of the resource to prune for", "GVK", options.GVK)
+			os.Exit(1)
+		}
+		if mapping.Scope.Name() == meta.RESTScopeNameRoot {
+			log.Error(fmt.Errorf("cluster-scoped resource %s cannot prune", options.GVK),
+				"Pruning is only supported for namespaced resources")
+			os.Exit(1)
+		}
+	}
+
 	//Create new controller runtime controller and set the controller to watch GVK.
 	c, err := controller.New(fmt.Sprintf("%v-%v-controller", strings.ToLower(options.GVK.Kind), strings.ToLower(options.GVK.Version)), mgr,
 		controller.Options{
@@ -126,7 +182,8 @@ func Add(mgr manager.Manager, options Options) *controller.Controller {
 
 	u := &unstructured.Unstructured{}
 	u.SetGroupVersionKind(options.GVK)
-	err = c.Watch(source.Kind(mgr.GetCache(), client.Object(u), handler.LoggingEnqueueRequestForObject{}, predicates...))
+	err = c.Watch(source.Kind(mgr.GetCache(), client.Object(u),
+		timedEventHandler{EventHandler: handler.LoggingEnqueueRequestForObject{}, times: aor.eventTimes}, predicates...))
 	if err != nil {
 		log.Error(err, "")
 		os.Exit(1)
@@ -135,6 +192,16 @@ func Add(mgr manager.Manager, options Options) *controller.Controller {
 	return &c
 }
 
+// impersonatedClient returns a function creating the clients of mgr making
+// their API calls as a user.
+func impersonatedClient(mgr manager.Manager) func(string) (client.Client, error) {
+	return func(user string) (client.Client, error) {
+		cfg := rest.CopyConfig(mgr.GetConfig())
+		cfg.Impersonate = rest.ImpersonationConfig{UserName: user}
+		return client.New(cfg, client.Options{Scheme: mgr.GetScheme(), Mapper: mgr.GetRESTMapper()})
+	}
+}
+
 // parsePredicateSelector parses the selector in the WatchOptions and creates a predicate
 // that is used to filter resources based on the specified selector
 func parsePredicateSelector(selector metav1.LabelSelector) (ctrlpredicate.Predicate, error) {
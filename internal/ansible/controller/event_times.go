@@ -0,0 +1,103 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	crhandler "sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// eventTimes - when the oldest event not yet reconciled was received, for
+// each request. Events for a request already queued are merged by the queue,
+// so only the first one is kept.
+type eventTimes struct {
+	mutex sync.Mutex
+	times map[reconcile.Request]time.Time
+}
+
+func newEventTimes() *eventTimes {
+	return &eventTimes{times: map[reconcile.Request]time.Time{}}
+}
+
+func (t *eventTimes) record(req reconcile.Request) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if _, ok := t.times[req]; !ok {
+		t.times[req] = time.Now()
+	}
+}
+
+// pop returns when the oldest event for the request was received, if any,
+// and forgets it.
+func (t *eventTimes) pop(req reconcile.Request) (time.Time, bool) {
+	if t == nil {
+		return time.Time{}, false
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	received, ok := t.times[req]
+	delete(t.times, req)
+	return received, ok
+}
+
+// timedEventHandler - wraps an EventHandler, recording when it queues each
+// request.
+type timedEventHandler struct {
+	crhandler.EventHandler
+	times *eventTimes
+}
+
+// Create implements EventHandler, and records when the request was queued.
+func (h timedEventHandler) Create(ctx context.Context, e event.CreateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.EventHandler.Create(ctx, e, timedQueue{q, h.times})
+}
+
+// Update implements EventHandler, and records when the request was queued.
+func (h timedEventHandler) Update(ctx context.Context, e event.UpdateEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.EventHandler.Update(ctx, e, timedQueue{q, h.times})
+}
+
+// Delete implements EventHandler, and records when the request was queued.
+func (h timedEventHandler) Delete(ctx context.Context, e event.DeleteEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.EventHandler.Delete(ctx, e, timedQueue{q, h.times})
+}
+
+// Generic implements EventHandler, and records when the request was queued.
+func (h timedEventHandler) Generic(ctx context.Context, e event.GenericEvent, q workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+	h.EventHandler.Generic(ctx, e, timedQueue{q, h.times})
+}
+
+// timedQueue - records when requests are added to the queue.
+type timedQueue struct {
+	workqueue.TypedRateLimitingInterface[reconcile.Request]
+	times *eventTimes
+}
+
+func (q timedQueue) Add(req reconcile.Request) {
+	q.times.record(req)
+	q.TypedRateLimitingInterface.Add(req)
+}
+
+var _ crhandler.TypedEventHandler[client.Object, reconcile.Request] = timedEventHandler{}
@@ -0,0 +1,190 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	toolscache "k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/config"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllertest"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	crhandler "sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/fake"
+)
+
+func TestTimedEventHandler(t *testing.T) {
+	times := newEventTimes()
+	h := timedEventHandler{EventHandler: &crhandler.EnqueueRequestForObject{}, times: times}
+	q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+	defer q.ShutDown()
+
+	u := &unstructured.Unstructured{}
+	u.SetName("example")
+	u.SetNamespace("default")
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "example", Namespace: "default"}}
+
+	before := time.Now()
+	h.Create(context.TODO(), event.CreateEvent{Object: u}, q)
+	h.Update(context.TODO(), event.UpdateEvent{ObjectOld: u, ObjectNew: u}, q)
+	if q.Len() != 1 {
+		t.Fatalf("Unexpected queue length %d", q.Len())
+	}
+
+	received, ok := times.pop(req)
+	if !ok || received.Before(before) || received.After(time.Now()) {
+		t.Fatalf("Unexpected event time %v, %v", received, ok)
+	}
+	if _, ok := times.pop(req); ok {
+		t.Fatalf("Expected the event time to be forgotten once popped")
+	}
+	if _, ok := (*eventTimes)(nil).pop(req); ok {
+		t.Fatalf("Expected no event time without a record")
+	}
+}
+
+func TestTimedEventHandlerWithoutTimes(t *testing.T) {
+	h := timedEventHandler{EventHandler: &crhandler.EnqueueRequestForObject{}}
+	q := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+	defer q.ShutDown()
+
+	u := &unstructured.Unstructured{}
+	u.SetName("example")
+	u.SetNamespace("default")
+	h.Create(context.TODO(), event.CreateEvent{Object: u}, q)
+	if q.Len() != 1 {
+		t.Fatalf("Unexpected queue length %d", q.Len())
+	}
+}
+
+func TestAddRecordsEventTimes(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "cache.example.com", Version: "v1alpha1", Kind: "EventTimes"}
+	cr := &unstructured.Unstructured{}
+	cr.SetGroupVersionKind(gvk)
+	cr.SetName("example")
+	cr.SetNamespace("default")
+
+	scheme := runtime.NewScheme()
+	informer := &lockedInformer{}
+	informers := &informertest.FakeInformers{
+		Scheme:         scheme,
+		InformersByGVK: map[schema.GroupVersionKind]toolscache.SharedIndexInformer{gvk: informer},
+	}
+	mgr, err := manager.New(&rest.Config{Host: "http://localhost"}, manager.Options{
+		Scheme:  scheme,
+		Metrics: metricsserver.Options{BindAddress: "0"},
+		Controller: config.Controller{
+			SkipNameValidation: ptr.To(true),
+		},
+		MapperProvider: func(*rest.Config, *http.Client) (meta.RESTMapper, error) {
+			return meta.NewDefaultRESTMapper(nil), nil
+		},
+		NewCache: func(*rest.Config, cache.Options) (cache.Cache, error) {
+			return informers, nil
+		},
+		NewClient: func(*rest.Config, client.Options) (client.Client, error) {
+			return fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(cr).Build(), nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	Add(mgr, Options{GVK: gvk, Runner: &fake.Runner{}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errs := make(chan error, 1)
+	go func() {
+		errs <- mgr.Start(ctx)
+	}()
+
+	// The event is added until the controller watches the informer and
+	// reconciles the resource.
+	timeout := time.After(10 * time.Second)
+	for reconcileStartDelays(t, gvk) == 0 {
+		informer.Add(cr)
+		select {
+		case err := <-errs:
+			t.Fatalf("Manager stopped: %v", err)
+		case <-timeout:
+			t.Fatalf("Expected the reconcile start delay to be observed")
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// lockedInformer - a FakeInformer its handlers can be added to while it
+// fakes events.
+type lockedInformer struct {
+	mutex sync.Mutex
+	controllertest.FakeInformer
+}
+
+func (i *lockedInformer) AddEventHandlerWithOptions(h toolscache.ResourceEventHandler,
+	options toolscache.HandlerOptions) (toolscache.ResourceEventHandlerRegistration, error) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	return i.FakeInformer.AddEventHandlerWithOptions(h, options)
+}
+
+func (i *lockedInformer) Add(obj metav1.Object) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+	i.FakeInformer.Add(obj)
+}
+
+// reconcileStartDelays returns how many reconcile start delays were observed
+// for gvk.
+func reconcileStartDelays(t *testing.T, gvk schema.GroupVersionKind) uint64 {
+	families, err := metrics.Registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range families {
+		if f.GetName() != "ansible_operator_reconcile_start_delay_seconds" {
+			continue
+		}
+		for _, m := range f.GetMetric() {
+			for _, l := range m.GetLabel() {
+				if l.GetName() == "GVK" && l.GetValue() == gvk.String() {
+					return m.GetHistogram().GetSampleCount()
+				}
+			}
+		}
+	}
+	return 0
+}
@@ -0,0 +1,173 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/watches"
+)
+
+const (
+	// outputsStatusField - field of the status holding the outputs.
+	outputsStatusField = "outputs"
+	// outputsConfigMapKey - key of the ConfigMap data holding the outputs as JSON.
+	outputsConfigMapKey = "outputs"
+)
+
+// outputsConfigMapName returns the name of the ConfigMap keeping the outputs of u.
+func outputsConfigMapName(u *unstructured.Unstructured) string {
+	return ownedConfigMapName(u, "ansible-outputs")
+}
+
+// ownedConfigMapName returns the name of a ConfigMap owned by u, ending with
+// suffix. The name includes the kind of u, so that the resources of different
+// kinds with the same name do not share it.
+func ownedConfigMapName(u *unstructured.Unstructured, suffix string) string {
+	return fmt.Sprintf("%s-%s-%s", u.GetName(), strings.ToLower(u.GroupVersionKind().GroupKind().String()), suffix)
+}
+
+// checkConfigMapOwner returns an error if cm is not owned by u, as when the
+// user created a ConfigMap with the same name, which is then left alone.
+func checkConfigMapOwner(cm *v1.ConfigMap, u *unstructured.Unstructured) error {
+	for _, ref := range cm.GetOwnerReferences() {
+		if ref.UID == u.GetUID() && ref.Kind == u.GetKind() && ref.Name == u.GetName() {
+			return nil
+		}
+	}
+	return fmt.Errorf("ConfigMap %s/%s is not owned by the resource", cm.GetNamespace(), cm.GetName())
+}
+
+// loadOutputs returns the outputs kept for u by the previous runs. It returns
+// an empty map, rather than nil, if none were kept yet.
+func (r *AnsibleOperatorReconciler) loadOutputs(ctx context.Context, u *unstructured.Unstructured) (map[string]interface{}, error) {
+	outputs := map[string]interface{}{}
+	switch r.OutputsStore {
+	case watches.OutputsStoreStatus:
+		kept, _, err := unstructured.NestedMap(u.Object, "status", outputsStatusField)
+		if err != nil {
+			return nil, err
+		}
+		if kept != nil {
+			outputs = kept
+		}
+		if r.StatusConverter != nil {
+			outputs = r.StatusConverter.MapToSnake(outputs)
+		}
+	case watches.OutputsStoreConfigMap:
+		if u.GetNamespace() == "" {
+			return nil, errors.New("outputs can only be kept in a ConfigMap for namespaced resources")
+		}
+		cm := &v1.ConfigMap{}
+		key := types.NamespacedName{Namespace: u.GetNamespace(), Name: outputsConfigMapName(u)}
+		if err := r.APIReader.Get(ctx, key, cm); err != nil {
+			if apierrors.IsNotFound(err) {
+				return outputs, nil
+			}
+			return nil, err
+		}
+		if err := checkConfigMapOwner(cm, u); err != nil {
+			return nil, err
+		}
+		if data, ok := cm.Data[outputsConfigMapKey]; ok {
+			if err := json.Unmarshal([]byte(data), &outputs); err != nil {
+				return nil, fmt.Errorf("unable to parse outputs in ConfigMap %s: %w", key, err)
+			}
+		}
+	}
+	return outputs, nil
+}
+
+// saveOutputs keeps outputs for u, to be passed to the next run.
+func (r *AnsibleOperatorReconciler) saveOutputs(ctx context.Context, nn types.NamespacedName, u *unstructured.Unstructured,
+	outputs map[string]interface{}) error {
+	switch r.OutputsStore {
+	case watches.OutputsStoreStatus:
+		// Get the latest resource to prevent updating a stale status.
+		if err := r.APIReader.Get(ctx, nn, u); err != nil {
+			return err
+		}
+		if r.StatusConverter != nil {
+			outputs = r.StatusConverter.MapToCamel(outputs)
+		}
+		if err := unstructured.SetNestedMap(u.Object, outputs, "status", outputsStatusField); err != nil {
+			return err
+		}
+		return r.Client.Status().Update(ctx, u)
+	case watches.OutputsStoreConfigMap:
+		data, err := json.Marshal(outputs)
+		if err != nil {
+			return err
+		}
+		cm := &v1.ConfigMap{}
+		key := types.NamespacedName{Namespace: u.GetNamespace(), Name: outputsConfigMapName(u)}
+		err = r.APIReader.Get(ctx, key, cm)
+		if apierrors.IsNotFound(err) {
+			cm = &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      key.Name,
+					Namespace: key.Namespace,
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: u.GetAPIVersion(),
+						Kind:       u.GetKind(),
+						Name:       u.GetName(),
+						UID:        u.GetUID(),
+					}},
+				},
+				Data: map[string]string{outputsConfigMapKey: string(data)},
+			}
+			return r.Client.Create(ctx, cm)
+		}
+		if err != nil {
+			return err
+		}
+		if err := checkConfigMapOwner(cm, u); err != nil {
+			return err
+		}
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[outputsConfigMapKey] = string(data)
+		return r.Client.Update(ctx, cm)
+	}
+	return nil
+}
+
+// mergeOutputs returns the previous outputs updated with the ones set by a
+// run. An output set to null is removed.
+func mergeOutputs(previous, set map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(previous)+len(set))
+	for k, v := range previous {
+		merged[k] = v
+	}
+	for k, v := range set {
+		if v == nil {
+			delete(merged, k)
+			continue
+		}
+		merged[k] = v
+	}
+	return merged
+}
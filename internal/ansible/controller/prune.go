@@ -0,0 +1,262 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	libhandler "github.com/operator-framework/operator-lib/handler"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/events"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/inventory"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+// inventoryConfigMapKey - key of the ConfigMap data holding the inventory as
+// JSON.
+const inventoryConfigMapKey = "inventory"
+
+// inventoryConfigMapName returns the name of the ConfigMap keeping the
+// inventory of u.
+func inventoryConfigMapName(u *unstructured.Unstructured) string {
+	return ownedConfigMapName(u, "ansible-inventory")
+}
+
+// pruneInventory prunes the objects of the inventory of u that the run did
+// not create or update, if prune is set, and keeps the objects of the run in
+// the inventory for the next run. Only the objects of the kinds that may be
+// pruned are kept. If prune is not set, as when the run failed, the objects
+// of the run are added to the inventory. The inventory is read again if
+// updated by another run meanwhile.
+func (r *AnsibleOperatorReconciler) pruneInventory(ctx context.Context, u *unstructured.Unstructured,
+	touched []inventory.Object, prune bool) error {
+	c, err := r.pruneClient(u)
+	if err != nil {
+		return err
+	}
+	return retry.OnError(retry.DefaultRetry, isInventoryConflict, func() error {
+		cm, previous, err := r.loadInventory(ctx, u)
+		if err != nil {
+			return err
+		}
+		previous, kept := r.prunable(previous), r.prunable(touched)
+		if !prune {
+			return r.saveInventory(ctx, u, cm, inventory.Union(previous, kept))
+		}
+
+		for _, o := range inventory.Difference(previous, kept) {
+			if err := r.pruneObject(ctx, c, u, o); err != nil {
+				// kept to be pruned after the next run.
+				logf.Log.WithName("reconciler").Error(err, "Failed to prune", "apiVersion", o.APIVersion,
+					"kind", o.Kind, "namespace", o.Namespace, "name", o.Name)
+				kept = append(kept, o)
+			}
+		}
+		inventory.Sort(kept)
+		return r.saveInventory(ctx, u, cm, kept)
+	})
+}
+
+// isInventoryConflict returns whether err is that the inventory was updated,
+// or created, by another run since read.
+func isInventoryConflict(err error) bool {
+	return apierrors.IsConflict(err) || apierrors.IsAlreadyExists(err)
+}
+
+// pruneClient returns the client the objects of the runs of u are pruned
+// with, which impersonates the ServiceAccount the runs make their API calls
+// as, if any, so that a run cannot have pruned what it could not delete.
+func (r *AnsibleOperatorReconciler) pruneClient(u *unstructured.Unstructured) (client.Client, error) {
+	user, err := r.Impersonate.User(u)
+	if err != nil || user == "" {
+		return r.Client, err
+	}
+	if r.ImpersonatedClient == nil {
+		return nil, fmt.Errorf("no client to prune as %s", user)
+	}
+	return r.ImpersonatedClient(user)
+}
+
+// finishRun takes the events of a run the reconcile returned before the end
+// of, as on a requeue, giving them to the dispatcher, and once the run
+// finished, prunes the objects of the previous runs it no longer produced, if
+// pruning and it succeeded.
+func (r *AnsibleOperatorReconciler) finishRun(ctx context.Context, ident string, nn types.NamespacedName,
+	result runner.RunResult, dispatcher *events.Dispatcher, pruning, failed bool) {
+	defer r.Writes.Stop(ident)
+	for event := range result.Events() {
+		dispatcher.Dispatch(event)
+		if event.Event == eventapi.EventRunnerOnFailed && !event.IgnoreError() && !event.Rescued() {
+			failed = true
+		}
+	}
+	dispatcher.Flush()
+	outcome, waitErr := result.Wait()
+	touched := r.Inventory.Stop(ident)
+	if !pruning {
+		return
+	}
+
+	logger := logf.Log.WithName("reconciler").WithValues("job", ident, "name", nn.Name, "namespace", nn.Namespace)
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(r.GVK)
+	if err := r.APIReader.Get(ctx, nn, u); err != nil {
+		if !apierrors.IsNotFound(err) {
+			logger.Error(err, "Failed to get the resource to prune the objects no longer produced by the run")
+		}
+		return
+	}
+	if u.GetDeletionTimestamp() != nil {
+		return
+	}
+	successful := waitErr == nil && !failed && outcome.Successful()
+	if err := r.pruneInventory(ctx, u, touched, successful); err != nil {
+		logger.Error(err, "Failed to prune the objects no longer produced by the run")
+	}
+}
+
+// prunable returns the objects of the kinds that may be pruned.
+func (r *AnsibleOperatorReconciler) prunable(objects []inventory.Object) []inventory.Object {
+	var prunable []inventory.Object
+	for _, o := range objects {
+		gk := o.GroupVersionKind().GroupKind()
+		for _, allowed := range r.PruneKinds {
+			if gk == allowed {
+				prunable = append(prunable, o)
+				break
+			}
+		}
+	}
+	return prunable
+}
+
+// pruneObject deletes o with c, if it is still owned by u. The objects no
+// longer owned by u, e.g. adopted by another resource, are left alone.
+func (r *AnsibleOperatorReconciler) pruneObject(ctx context.Context, c client.Client, u *unstructured.Unstructured,
+	o inventory.Object) error {
+	logger := logf.Log.WithName("reconciler").WithValues("apiVersion", o.APIVersion, "kind", o.Kind,
+		"namespace", o.Namespace, "name", o.Name)
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(o.GroupVersionKind())
+	err := r.APIReader.Get(ctx, types.NamespacedName{Namespace: o.Namespace, Name: o.Name}, obj)
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !ownedBy(obj, u) {
+		logger.Info("Not pruning an object no longer owned by the resource")
+		return nil
+	}
+	uid := obj.GetUID()
+	err = c.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground),
+		client.Preconditions{UID: &uid})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	logger.Info("Pruned an object no longer produced by the run")
+	return nil
+}
+
+// ownedBy returns whether obj is owned by u, with an owner reference or with
+// the owner annotations set when an owner reference cannot be.
+func ownedBy(obj, u *unstructured.Unstructured) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == u.GetUID() {
+			return true
+		}
+	}
+	annotations := obj.GetAnnotations()
+	return annotations[libhandler.NamespacedNameAnnotation] == u.GetNamespace()+"/"+u.GetName() &&
+		annotations[libhandler.TypeAnnotation] == u.GroupVersionKind().GroupKind().String()
+}
+
+// loadInventory returns the ConfigMap keeping the inventory of u, or nil if
+// none, and the inventory kept by the previous runs.
+func (r *AnsibleOperatorReconciler) loadInventory(ctx context.Context,
+	u *unstructured.Unstructured) (*v1.ConfigMap, []inventory.Object, error) {
+	if u.GetNamespace() == "" {
+		return nil, nil, errors.New("the inventory can only be kept in a ConfigMap for namespaced resources")
+	}
+	cm := &v1.ConfigMap{}
+	key := types.NamespacedName{Namespace: u.GetNamespace(), Name: inventoryConfigMapName(u)}
+	if err := r.APIReader.Get(ctx, key, cm); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	if err := checkConfigMapOwner(cm, u); err != nil {
+		return nil, nil, err
+	}
+	var objects []inventory.Object
+	if data, ok := cm.Data[inventoryConfigMapKey]; ok {
+		if err := json.Unmarshal([]byte(data), &objects); err != nil {
+			return nil, nil, fmt.Errorf("unable to parse inventory in ConfigMap %s: %w", key, err)
+		}
+	}
+	return cm, objects, nil
+}
+
+// saveInventory keeps objects as the inventory of u, for the next run, in cm
+// as loaded by loadInventory. The update of cm fails with a conflict if
+// updated since loaded, as the creation does if created since.
+func (r *AnsibleOperatorReconciler) saveInventory(ctx context.Context, u *unstructured.Unstructured,
+	cm *v1.ConfigMap, objects []inventory.Object) error {
+	if objects == nil {
+		objects = []inventory.Object{}
+	}
+	data, err := json.Marshal(objects)
+	if err != nil {
+		return err
+	}
+	if cm == nil {
+		cm = &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      inventoryConfigMapName(u),
+				Namespace: u.GetNamespace(),
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: u.GetAPIVersion(),
+					Kind:       u.GetKind(),
+					Name:       u.GetName(),
+					UID:        u.GetUID(),
+				}},
+			},
+			Data: map[string]string{inventoryConfigMapKey: string(data)},
+		}
+		return r.Client.Create(ctx, cm)
+	}
+	if cm.Data[inventoryConfigMapKey] == string(data) {
+		return nil
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[inventoryConfigMapKey] = string(data)
+	return r.Client.Update(ctx, cm)
+}
@@ -25,6 +25,9 @@ import (
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -38,10 +41,19 @@ import (
 
 	ansiblestatus "github.com/operator-framework/ansible-operator-plugins/internal/ansible/controller/status"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/events"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/impersonation"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/metrics"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/outputs"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/paramconv"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/controllermap"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/credentials"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/inventory"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/kubeconfig"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/writes"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runs"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/tracing"
 )
 
 const (
@@ -62,15 +74,80 @@ type AnsibleOperatorReconciler struct {
 	ManageStatus            bool
 	AnsibleDebugLogs        bool
 	WatchAnnotationsChanges bool
+	// OutputsStore - where the outputs of a run are kept for the next run. The
+	// outputs are not kept when empty.
+	OutputsStore string
+	// Outputs - collects the outputs the runs post to the apiserver, kept
+	// along with the outputs set by their events if OutputsStore is set.
+	Outputs *outputs.Tracker
+	// StatusConverter - converts the keys of the outputs kept in the status
+	// to camelCase, and back to the snake_case the runs set them in, if set.
+	StatusConverter *paramconv.Converter
+	// EventDispatch - how the events of a run are queued for each handler.
+	EventDispatch events.DispatchOptions
+	// LogFormat - how the stdout of ansible is logged.
+	LogFormat events.LogFormat
+	// Runs - tracks the runs for introspection, if set.
+	Runs *runs.Tracker
+	// Credentials - issues the tokens the runs authenticate to the proxy
+	// with. The runs are given no token if unset.
+	Credentials *credentials.Store
+	// Impersonate - the service account the runs make their API calls as,
+	// if set, rather than as the operator.
+	Impersonate *impersonation.ServiceAccount
+	// ImpersonatedClient - returns a client making its API calls as user,
+	// which the objects of the runs impersonating a ServiceAccount are pruned
+	// with.
+	ImpersonatedClient func(user string) (client.Client, error)
+	// Inventory - records the objects the runs create or update, to prune
+	// those a run no longer produces.
+	Inventory *inventory.Recorder
+	// PruneKinds - the kinds of the objects pruned once a run no longer
+	// produces them. Nothing is pruned if empty.
+	PruneKinds []schema.GroupKind
+	// Writes - tracks the objects the runs write, for the cache of the proxy
+	// to serve their reads once it observed their writes.
+	Writes *writes.Tracker
+	// ControllerMap - the dependent watches of the controllers, released by
+	// the resources once removed, if set.
+	ControllerMap *controllermap.ControllerMap
+	// ProxyURL - the URL of the proxy the runs make their API calls through.
+	// Defaults to http://localhost:8888.
+	ProxyURL string
+
+	// eventTimes records when the events on the watched resources were
+	// received, if set.
+	eventTimes *eventTimes
 }
 
 // Reconcile - handle the event.
-func (r *AnsibleOperatorReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) { //nolint:gocyclo
+func (r *AnsibleOperatorReconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "Reconcile", trace.WithAttributes(
+		attribute.String("k8s.gvk", r.GVK.String()),
+		attribute.String("k8s.namespace", request.Namespace),
+		attribute.String("k8s.name", request.Name),
+	))
+	defer span.End()
+	result, err := r.reconcile(ctx, request)
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return result, err
+}
+
+func (r *AnsibleOperatorReconciler) reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) { //nolint:gocyclo
 	// TODO: Try to reduce the complexity of this last measured at 42 (failing at > 30) and remove the // nolint:gocyclo
+	if received, ok := r.eventTimes.pop(request); ok {
+		metrics.ReconcileStartDelay(r.GVK.String(), time.Since(received))
+	}
 	u := &unstructured.Unstructured{}
 	u.SetGroupVersionKind(r.GVK)
 	err := r.Client.Get(ctx, request.NamespacedName, u)
 	if apierrors.IsNotFound(err) {
+		// The resource was removed, its user metrics go with it, and the
+		// watches of its dependents are no longer used by it.
+		metrics.DeleteCRUserMetrics(r.GVK.GroupKind(), request.Namespace, request.Name)
+		r.ControllerMap.Release(r.GVK, request.NamespacedName)
 		return reconcile.Result{}, nil
 	}
 	if err != nil {
@@ -128,6 +205,7 @@ func (r *AnsibleOperatorReconciler) Reconcile(ctx context.Context, request recon
 		u.Object["spec"] = map[string]interface{}{}
 	}
 
+	lastSuccessfulRun := lastSuccessfulResult(u)
 	if r.ManageStatus {
 		errmark := r.markRunning(ctx, request.NamespacedName, u)
 		if errmark != nil {
@@ -143,7 +221,44 @@ func (r *AnsibleOperatorReconciler) Reconcile(ctx context.Context, request recon
 		UID:        u.GetUID(),
 	}
 
-	kc, err := kubeconfig.Create(ownerRef, "http://localhost:8888", u.GetNamespace())
+	impersonate, err := r.Impersonate.User(u)
+	if err != nil {
+		errmark := r.markError(ctx, request.NamespacedName, u, err.Error())
+		if errmark != nil {
+			logger.Error(errmark, "Unable to mark error to run reconciliation")
+		}
+		logger.Error(err, "Unable to get the service account to impersonate")
+		return reconcileResult, err
+	}
+
+	// the run authenticates to the proxy with a token of its own, revoked
+	// when the run is done.
+	token, err := r.Credentials.Issue(credentials.Credential{
+		Owner:       kubeconfig.NamespacedOwnerReference{OwnerReference: ownerRef, Namespace: u.GetNamespace()},
+		Ident:       ident,
+		TraceParent: tracing.TraceParent(ctx),
+		Impersonate: impersonate,
+	})
+	if err != nil {
+		errmark := r.markError(ctx, request.NamespacedName, u, "Unable to run reconciliation")
+		if errmark != nil {
+			logger.Error(errmark, "Unable to mark error to run reconciliation")
+		}
+		logger.Error(err, "Unable to issue a proxy token")
+		return reconcileResult, err
+	}
+	runStarted := false
+	defer func() {
+		if !runStarted {
+			r.Credentials.Revoke(token)
+		}
+	}()
+
+	proxyURL := r.ProxyURL
+	if proxyURL == "" {
+		proxyURL = "http://localhost:8888"
+	}
+	kc, err := kubeconfig.Create(proxyURL, u.GetNamespace(), token)
 	if err != nil {
 		errmark := r.markError(ctx, request.NamespacedName, u, "Unable to run reconciliation")
 		if errmark != nil {
@@ -157,8 +272,38 @@ func (r *AnsibleOperatorReconciler) Reconcile(ctx context.Context, request recon
 			logger.Error(err, "Failed to remove generated kubeconfig file")
 		}
 	}()
-	result, err := r.Runner.Run(ident, u, kc.Name())
+
+	var previousOutputs map[string]interface{}
+	if r.OutputsStore != "" {
+		previousOutputs, err = r.loadOutputs(ctx, u)
+		if err != nil {
+			errmark := r.markError(ctx, request.NamespacedName, u, "Unable to load the outputs of the previous run")
+			if errmark != nil {
+				logger.Error(errmark, "Unable to mark error to run reconciliation")
+			}
+			logger.Error(err, "Unable to load the outputs of the previous run")
+			return reconcileResult, err
+		}
+	}
+
+	// the objects of the run are recorded to prune those it no longer
+	// produces, unless the custom resource is being deleted.
+	pruning := len(r.PruneKinds) > 0 && !deleted
+	if pruning {
+		r.Inventory.Start(ident)
+	}
+	// the reads of the run reflect its writes.
+	r.Writes.Start(ident)
+	if r.OutputsStore != "" {
+		r.Outputs.Start(ident)
+	}
+	defer r.Outputs.Stop(ident)
+
+	// the token of the run also authenticates the outputs it posts.
+	result, err := r.Runner.Run(ctx, ident, u, kc.Name(), token, previousOutputs, lastSuccessfulRun)
 	if err != nil {
+		r.Inventory.Stop(ident)
+		r.Writes.Stop(ident)
 		errmark := r.markError(ctx, request.NamespacedName, u, "Unable to run reconciliation")
 		if errmark != nil {
 			logger.Error(errmark, "Unable to mark error to run reconciliation")
@@ -166,24 +311,52 @@ func (r *AnsibleOperatorReconciler) Reconcile(ctx context.Context, request recon
 		logger.Error(err, "Unable to run ansible runner")
 		return reconcileResult, err
 	}
+	runStarted = true
+	go func() {
+		_, _ = result.Wait()
+		r.Credentials.Revoke(token)
+	}()
+	// The events the reconcile does not take, when it returns before the run
+	// finished, as on a requeue, are taken in the background so that the run
+	// is not held back for good, and the run is pruned once finished. They
+	// are still given to the handlers, which are otherwise done with the
+	// events of the run by the time the reconcile returns.
+	dispatcher := events.NewDispatcher(ident, u, r.EventHandlers, r.EventDispatch)
+	eventsTaken := false
+	failed := false
+	defer func() {
+		if eventsTaken {
+			dispatcher.Flush()
+			r.Inventory.Stop(ident)
+			r.Writes.Stop(ident)
+			return
+		}
+		go r.finishRun(context.WithoutCancel(ctx), ident, request.NamespacedName, result, dispatcher, pruning,
+			failed)
+	}()
+
+	// the run is tracked until the reconcile stops following it.
+	var outcome runner.Outcome
+	trackedRun := r.Runs.Start(ident, u)
+	defer func() { trackedRun.Finish(outcome) }()
 
 	// iterate events from ansible, looking for the final one
 	statusEvent := eventapi.StatusJobEvent{}
 	failureMessages := eventapi.FailureMessages{}
+	setOutputs := map[string]interface{}{}
 	for event := range result.Events() {
-		for _, eHandler := range r.EventHandlers {
-			go eHandler.Handle(ident, u, event)
-		}
+		dispatcher.Dispatch(event)
+		trackedRun.Event(event)
 		if event.Event == eventapi.EventPlaybookOnStats {
 			// convert to StatusJobEvent; would love a better way to do this
 			data, err := json.Marshal(event)
 			if err != nil {
-				printEventStats(statusEvent, u)
+				r.printEventStats(statusEvent, u)
 				return reconcile.Result{}, err
 			}
 			err = json.Unmarshal(data, &statusEvent)
 			if err != nil {
-				printEventStats(statusEvent, u)
+				r.printEventStats(statusEvent, u)
 				return reconcile.Result{}, err
 			}
 		}
@@ -203,25 +376,53 @@ func (r *AnsibleOperatorReconciler) Reconcile(ctx context.Context, request recon
 				}
 			}
 		}
+		if outputs, ok := event.GetOutputs(); ok {
+			for k, v := range outputs {
+				setOutputs[k] = v
+			}
+		}
 		if event.Event == eventapi.EventRunnerOnFailed && !event.IgnoreError() && !event.Rescued() {
 			failureMessages = append(failureMessages, event.GetFailedPlaybookMessage())
+			failed = true
 		}
 	}
+	eventsTaken = true
+	// the outputs the run posted are set over those set by its events.
+	for k, v := range r.Outputs.Stop(ident) {
+		setOutputs[k] = v
+	}
 
 	// To print the stats of the task
-	printEventStats(statusEvent, u)
+	r.printEventStats(statusEvent, u)
 
 	// To print the full ansible result
 	r.printAnsibleResult(result, u)
 
+	outcome, err = result.Wait()
+	if err != nil {
+		errmark := r.markError(ctx, request.NamespacedName, u, "Unable to run reconciliation")
+		if errmark != nil {
+			logger.Error(errmark, "Unable to mark error to run reconciliation")
+		}
+		logger.Error(err, "Unable to run reconciliation")
+		return reconcileResult, err
+	}
+	logger.V(1).Info("Ansible run finished", "rc", outcome.RC, "status", outcome.Status,
+		"duration", outcome.EndTime.Sub(outcome.StartTime).String(), "artifacts", outcome.ArtifactPath)
+
 	if statusEvent.Event == "" {
-		eventErr := errors.New("did not receive playbook_on_stats event")
+		// ansible exited before the playbook finished, so there are no task
+		// results to report.
+		eventErr := fmt.Errorf("did not receive playbook_on_stats event: ansible exited with rc %d and status %s",
+			outcome.RC, outcome.Status)
+		errmark := r.markError(ctx, request.NamespacedName, u,
+			fmt.Sprintf("Ansible exited with rc %d and status %s before the playbook finished",
+				outcome.RC, outcome.Status))
+		if errmark != nil {
+			logger.Error(errmark, "Unable to mark error to run reconciliation")
+		}
 		stdout, err := result.Stdout()
 		if err != nil {
-			errmark := r.markError(ctx, request.NamespacedName, u, "Failed to get ansible-runner stdout")
-			if errmark != nil {
-				logger.Error(errmark, "Unable to mark error to run reconciliation")
-			}
 			logger.Error(err, "Failed to get ansible-runner stdout")
 			return reconcileResult, err
 		}
@@ -229,6 +430,13 @@ func (r *AnsibleOperatorReconciler) Reconcile(ctx context.Context, request recon
 		return reconcileResult, eventErr
 	}
 
+	// The playbook finished, but ansible did not exit successfully without
+	// any failed task, e.g. because it timed out after the last task.
+	if len(failureMessages) == 0 && !outcome.Successful() {
+		failureMessages = append(failureMessages,
+			fmt.Sprintf("ansible exited with rc %d and status %s", outcome.RC, outcome.Status))
+	}
+
 	// Need to get the unstructured object after the Ansible runner finishes.
 	// This needs to hit the API server to retrieve updates.
 	err = r.APIReader.Get(ctx, request.NamespacedName, u)
@@ -245,6 +453,25 @@ func (r *AnsibleOperatorReconciler) Reconcile(ctx context.Context, request recon
 
 	recentlyDeleted := u.GetDeletionTimestamp() != nil
 
+	// Keep the outputs for the next run, even if this one failed, so that
+	// the bookkeeping of the tasks that did complete is not lost.
+	if r.OutputsStore != "" && len(setOutputs) > 0 && !deleted {
+		err := r.saveOutputs(ctx, request.NamespacedName, u, mergeOutputs(previousOutputs, setOutputs))
+		if err != nil {
+			logger.Error(err, "Failed to save the outputs of the run")
+			return reconcileResult, err
+		}
+	}
+
+	// Prune the objects of the previous runs this one no longer produced, if
+	// it succeeded, and keep the inventory of its objects for the next run.
+	if pruning && !recentlyDeleted {
+		if err := r.pruneInventory(ctx, u, r.Inventory.Stop(ident), runSuccessful); err != nil {
+			logger.Error(err, "Failed to prune the objects no longer produced by the run")
+			return reconcileResult, err
+		}
+	}
+
 	// The finalizer has run successfully, time to remove it
 	if deleted && finalizerExists && runSuccessful {
 		controllerutil.RemoveFinalizer(u, finalizer)
@@ -276,8 +503,16 @@ func (r *AnsibleOperatorReconciler) Reconcile(ctx context.Context, request recon
 	return reconcileResult, nil
 }
 
-func printEventStats(statusEvent eventapi.StatusJobEvent, u *unstructured.Unstructured) {
+func (r *AnsibleOperatorReconciler) printEventStats(statusEvent eventapi.StatusJobEvent, u *unstructured.Unstructured) {
 	if len(statusEvent.StdOut) > 0 {
+		if r.LogFormat == events.JSONLogFormat {
+			logf.Log.WithName("reconciler").Info("Ansible task status", "gvk", u.GroupVersionKind().String(),
+				"name", u.GetName(), "namespace", u.GetNamespace(),
+				"ok", statusEvent.EventData.Ok, "changed", statusEvent.EventData.Changed,
+				"skipped", statusEvent.EventData.Skipped, "failures", statusEvent.EventData.Failures,
+				"stdout", statusEvent.StdOut)
+			return
+		}
 		str := fmt.Sprintf("Ansible Task Status Event StdOut (%s, %s/%s)", u.GroupVersionKind(), u.GetName(), u.GetNamespace())
 		fmt.Printf("\n----- %70s -----\n\n%s\n\n----------\n", str, statusEvent.StdOut)
 	}
@@ -286,6 +521,11 @@ func printEventStats(statusEvent eventapi.StatusJobEvent, u *unstructured.Unstru
 func (r *AnsibleOperatorReconciler) printAnsibleResult(result runner.RunResult, u *unstructured.Unstructured) {
 	if r.AnsibleDebugLogs {
 		if res, err := result.Stdout(); err == nil && len(res) > 0 {
+			if r.LogFormat == events.JSONLogFormat {
+				logf.Log.WithName("reconciler").Info("Ansible debug result", "gvk", u.GroupVersionKind().String(),
+					"name", u.GetName(), "namespace", u.GetNamespace(), "stdout", res)
+				return
+			}
 			str := fmt.Sprintf("Ansible Debug Result (%s, %s/%s)", u.GroupVersionKind(), u.GetName(), u.GetNamespace())
 			fmt.Printf("\n----- %70s -----\n\n%s\n\n----------\n", str, res)
 		}
@@ -406,6 +646,7 @@ func (r *AnsibleOperatorReconciler) markDone(ctx context.Context, nn types.Names
 		ansiblestatus.SetCondition(&crStatus, *deprecatedRunningCondition)
 		ansiblestatus.SetCondition(&crStatus, *successfulCondition)
 		ansiblestatus.SetCondition(&crStatus, *failureCondition)
+		crStatus.LastSuccessfulRun = ansibleStatus
 	} else {
 		metrics.ReconcileFailed(r.GVK.String())
 		sc := ansiblestatus.GetCondition(crStatus, ansiblestatus.RunningConditionType)
@@ -436,6 +677,16 @@ func (r *AnsibleOperatorReconciler) markDone(ctx context.Context, nn types.Names
 	return r.Client.Status().Update(ctx, u)
 }
 
+// lastSuccessfulResult returns the result of the last successful run recorded
+// in the status of u, or nil if there is none.
+func lastSuccessfulResult(u *unstructured.Unstructured) map[string]interface{} {
+	result, ok, _ := unstructured.NestedMap(u.Object, "status", "lastSuccessfulRun")
+	if !ok {
+		return nil
+	}
+	return result
+}
+
 // getStatus returns u's "status" block as a status.Status.
 func getStatus(u *unstructured.Unstructured) ansiblestatus.Status {
 	statusInterface := u.Object["status"]
@@ -16,23 +16,40 @@ package controller_test
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
 	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/apiserver"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/controller"
 	ansiblestatus "github.com/operator-framework/ansible-operator-plugins/internal/ansible/controller/status"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/events"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/impersonation"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/outputs"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/paramconv"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/credentials"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/inventory"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/fake"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/watches"
 )
 
 // The behaviour of fake client has changed with
@@ -224,6 +241,136 @@ func TestReconcile(t *testing.T) {
 			},
 			ShouldError: true,
 		},
+		{
+			Name:         "ansible exited before the playbook finished",
+			GVK:          gvk,
+			ManageStatus: true,
+			Runner: &fake.Runner{
+				JobEvents: []eventapi.JobEvent{
+					{
+						Event:   eventapi.EventPlaybookOnTaskStart,
+						Created: eventapi.EventTime{Time: eventTime},
+					},
+				},
+				RC: 1,
+			},
+			Client: getFakeClientFromObject(&unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"name":      "reconcile",
+						"namespace": "default",
+					},
+					"apiVersion": "operator-sdk/v1beta1",
+					"kind":       "Testing",
+					"spec":       map[string]interface{}{},
+				},
+			}, true),
+			Request: reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "reconcile",
+					Namespace: "default",
+				},
+			},
+			ExpectedObject: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"name":      "reconcile",
+						"namespace": "default",
+					},
+					"apiVersion": "operator-sdk/v1beta1",
+					"kind":       "Testing",
+					"spec":       map[string]interface{}{},
+					"status": map[string]interface{}{
+						"conditions": []interface{}{
+							map[string]interface{}{
+								"status":  "False",
+								"type":    "Running",
+								"message": "Running reconciliation",
+								"reason":  "Running",
+							},
+							map[string]interface{}{
+								"status":  "True",
+								"type":    "Failure",
+								"message": "Ansible exited with rc 1 and status failed before the playbook finished",
+								"reason":  "Failed",
+							},
+						},
+					},
+				},
+			},
+			ShouldError: true,
+		},
+		{
+			Name:         "ansible timed out after the last task",
+			GVK:          gvk,
+			ManageStatus: true,
+			Runner: &fake.Runner{
+				JobEvents: []eventapi.JobEvent{
+					{
+						Event:   eventapi.EventPlaybookOnStats,
+						Created: eventapi.EventTime{Time: eventTime},
+					},
+				},
+				RC:     254,
+				Status: runner.RunTimeout,
+			},
+			Client: getFakeClientFromObject(&unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"name":      "reconcile",
+						"namespace": "default",
+					},
+					"apiVersion": "operator-sdk/v1beta1",
+					"kind":       "Testing",
+					"spec":       map[string]interface{}{},
+				},
+			}, true),
+			Request: reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "reconcile",
+					Namespace: "default",
+				},
+			},
+			ExpectedObject: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"name":      "reconcile",
+						"namespace": "default",
+					},
+					"apiVersion": "operator-sdk/v1beta1",
+					"kind":       "Testing",
+					"spec":       map[string]interface{}{},
+					"status": map[string]interface{}{
+						"conditions": []interface{}{
+							map[string]interface{}{
+								"status":  "False",
+								"type":    "Running",
+								"message": "Running reconciliation",
+								"reason":  "Running",
+							},
+							map[string]interface{}{
+								"status": "True",
+								"type":   "Failure",
+								"ansibleResult": map[string]interface{}{
+									"changed":    int64(0),
+									"failures":   int64(0),
+									"ok":         int64(0),
+									"skipped":    int64(0),
+									"completion": eventTime.Format("2006-01-02T15:04:05.99999999+00:00"),
+								},
+								"message": "ansible exited with rc 254 and status timeout",
+								"reason":  "Failed",
+							},
+							map[string]interface{}{
+								"status": "False",
+								"type":   "Successful",
+							},
+						},
+					},
+				},
+			},
+			ShouldError: true,
+		},
 		{
 			Name:         "Failure event runner on failed",
 			GVK:          gvk,
@@ -576,3 +723,722 @@ func getFakeClientFromObject(obj *unstructured.Unstructured, withStatus bool) cl
 	}
 	return fakeclient.NewClientBuilder().WithObjects(obj).Build()
 }
+
+func TestReconcileOutputs(t *testing.T) {
+	gvk := schema.GroupVersionKind{
+		Kind:    "Testing",
+		Group:   "operator-sdk",
+		Version: "v1beta1",
+	}
+	nn := types.NamespacedName{Name: "reconcile", Namespace: "default"}
+	newCR := func() *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"name":      nn.Name,
+					"namespace": nn.Namespace,
+				},
+				"apiVersion": "operator-sdk/v1beta1",
+				"kind":       "Testing",
+				"status": map[string]interface{}{
+					"outputs": map[string]interface{}{"revision": "v1", "stale": "yes"},
+				},
+			},
+		}
+	}
+	setOutputEvent := eventapi.JobEvent{
+		Event: eventapi.EventRunnerOnOk,
+		EventData: map[string]interface{}{
+			"task_action": eventapi.TaskActionSetOutput,
+			"res": map[string]interface{}{
+				"outputs": map[string]interface{}{"revision": "v2", "stale": nil},
+			},
+		},
+	}
+	statsEvent := eventapi.JobEvent{Event: eventapi.EventPlaybookOnStats}
+
+	testCases := []struct {
+		name             string
+		store            string
+		objects          []client.Object
+		expectedPrevious map[string]interface{}
+		getOutputs       func(c client.Client) (map[string]interface{}, error)
+	}{
+		{
+			name:             "status",
+			store:            watches.OutputsStoreStatus,
+			expectedPrevious: map[string]interface{}{"revision": "v1", "stale": "yes"},
+			getOutputs: func(c client.Client) (map[string]interface{}, error) {
+				u := &unstructured.Unstructured{}
+				u.SetGroupVersionKind(gvk)
+				if err := c.Get(context.TODO(), nn, u); err != nil {
+					return nil, err
+				}
+				outputs, _, err := unstructured.NestedMap(u.Object, "status", "outputs")
+				return outputs, err
+			},
+		},
+		{
+			name:  "configmap",
+			store: watches.OutputsStoreConfigMap,
+			objects: []client.Object{&v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "reconcile-testing.operator-sdk-ansible-outputs",
+					Namespace: nn.Namespace,
+					OwnerReferences: []metav1.OwnerReference{{APIVersion: "operator-sdk/v1beta1", Kind: "Testing",
+						Name: nn.Name}},
+				},
+				Data: map[string]string{"outputs": `{"revision":"v0"}`},
+			}},
+			expectedPrevious: map[string]interface{}{"revision": "v0"},
+			getOutputs: func(c client.Client) (map[string]interface{}, error) {
+				cm := &v1.ConfigMap{}
+				key := types.NamespacedName{Name: "reconcile-testing.operator-sdk-ansible-outputs",
+					Namespace: nn.Namespace}
+				if err := c.Get(context.TODO(), key, cm); err != nil {
+					return nil, err
+				}
+				outputs := map[string]interface{}{}
+				err := json.Unmarshal([]byte(cm.Data["outputs"]), &outputs)
+				return outputs, err
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := newCR()
+			c := fakeclient.NewClientBuilder().WithStatusSubresource(cr).WithObjects(cr).
+				WithObjects(tc.objects...).Build()
+			fakeRunner := &fake.Runner{JobEvents: []eventapi.JobEvent{setOutputEvent, statsEvent}}
+			aor := &controller.AnsibleOperatorReconciler{
+				GVK:          gvk,
+				Runner:       fakeRunner,
+				Client:       c,
+				APIReader:    c,
+				OutputsStore: tc.store,
+			}
+			if _, err := aor.Reconcile(context.TODO(), reconcile.Request{NamespacedName: nn}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(fakeRunner.PreviousOutputs, tc.expectedPrevious) {
+				t.Fatalf("Unexpected previous outputs\nexpected: %v\nactual: %v", tc.expectedPrevious,
+					fakeRunner.PreviousOutputs)
+			}
+			outputs, err := tc.getOutputs(c)
+			if err != nil {
+				t.Fatalf("Failed to get outputs: %v", err)
+			}
+			if expected := map[string]interface{}{"revision": "v2"}; !reflect.DeepEqual(outputs, expected) {
+				t.Fatalf("Unexpected outputs\nexpected: %v\nactual: %v", expected, outputs)
+			}
+		})
+	}
+}
+
+func TestReconcileOutputsStatusConversion(t *testing.T) {
+	gvk := schema.GroupVersionKind{
+		Kind:    "Testing",
+		Group:   "operator-sdk",
+		Version: "v1beta1",
+	}
+	nn := types.NamespacedName{Name: "reconcile", Namespace: "default"}
+	cr := &unstructured.Unstructured{}
+	cr.SetGroupVersionKind(gvk)
+	cr.SetName(nn.Name)
+	cr.SetNamespace(nn.Namespace)
+	cr.Object["status"] = map[string]interface{}{
+		"outputs": map[string]interface{}{"lastRolloutID": "v1"},
+	}
+	c := fakeclient.NewClientBuilder().WithStatusSubresource(cr).WithObjects(cr).Build()
+	fakeRunner := &fake.Runner{JobEvents: []eventapi.JobEvent{
+		{
+			Event: eventapi.EventRunnerOnOk,
+			EventData: map[string]interface{}{
+				"task_action": eventapi.TaskActionSetOutput,
+				"res": map[string]interface{}{
+					"outputs": map[string]interface{}{"last_rollout_id": "v2"},
+				},
+			},
+		},
+		{Event: eventapi.EventPlaybookOnStats},
+	}}
+	aor := &controller.AnsibleOperatorReconciler{
+		GVK:             gvk,
+		Runner:          fakeRunner,
+		Client:          c,
+		APIReader:       c,
+		OutputsStore:    watches.OutputsStoreStatus,
+		StatusConverter: paramconv.NewConverter(paramconv.Rules{WordMappings: map[string]string{"id": "ID"}}),
+	}
+	if _, err := aor.Reconcile(context.TODO(), reconcile.Request{NamespacedName: nn}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// the run is given the outputs in snake_case, which are kept in camelCase.
+	if expected := map[string]interface{}{"last_rollout_id": "v1"}; !reflect.DeepEqual(fakeRunner.PreviousOutputs,
+		expected) {
+		t.Fatalf("Unexpected previous outputs\nexpected: %v\nactual: %v", expected, fakeRunner.PreviousOutputs)
+	}
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	if err := c.Get(context.TODO(), nn, u); err != nil {
+		t.Fatal(err)
+	}
+	outputs, _, _ := unstructured.NestedMap(u.Object, "status", "outputs")
+	if expected := map[string]interface{}{"lastRolloutID": "v2"}; !reflect.DeepEqual(outputs, expected) {
+		t.Fatalf("Unexpected outputs\nexpected: %v\nactual: %v", expected, outputs)
+	}
+}
+
+func TestReconcileOutputsNotOwned(t *testing.T) {
+	gvk := schema.GroupVersionKind{
+		Kind:    "Testing",
+		Group:   "operator-sdk",
+		Version: "v1beta1",
+	}
+	nn := types.NamespacedName{Name: "reconcile", Namespace: "default"}
+	cr := &unstructured.Unstructured{}
+	cr.SetGroupVersionKind(gvk)
+	cr.SetName(nn.Name)
+	cr.SetNamespace(nn.Namespace)
+	cr.SetUID("cr-uid")
+	// a ConfigMap of the user, with the name the outputs would be kept in.
+	key := types.NamespacedName{Name: "reconcile-testing.operator-sdk-ansible-outputs", Namespace: nn.Namespace}
+	userConfigMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+		Data:       map[string]string{"outputs": `{"user":"data"}`},
+	}
+	c := fakeclient.NewClientBuilder().WithStatusSubresource(cr).WithObjects(cr, userConfigMap).Build()
+	fakeRunner := &fake.Runner{JobEvents: []eventapi.JobEvent{{Event: eventapi.EventPlaybookOnStats}}}
+	aor := &controller.AnsibleOperatorReconciler{
+		GVK:          gvk,
+		Runner:       fakeRunner,
+		Client:       c,
+		APIReader:    c,
+		OutputsStore: watches.OutputsStoreConfigMap,
+	}
+	if _, err := aor.Reconcile(context.TODO(), reconcile.Request{NamespacedName: nn}); err == nil {
+		t.Fatal("Expected an error for the ConfigMap not owned by the resource")
+	}
+	cm := &v1.ConfigMap{}
+	if err := c.Get(context.TODO(), key, cm); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cm.Data, userConfigMap.Data) || len(cm.OwnerReferences) != 0 {
+		t.Fatalf("Unexpected change to the ConfigMap of the user: %v", cm)
+	}
+}
+
+func TestReconcileLastSuccessfulRun(t *testing.T) {
+	gvk := schema.GroupVersionKind{
+		Kind:    "Testing",
+		Group:   "operator-sdk",
+		Version: "v1beta1",
+	}
+	nn := types.NamespacedName{Name: "reconcile", Namespace: "default"}
+	cr := &unstructured.Unstructured{}
+	cr.SetGroupVersionKind(gvk)
+	cr.SetName(nn.Name)
+	cr.SetNamespace(nn.Namespace)
+	eventTime := time.Now()
+	c := getFakeClientFromObject(cr, true)
+	fakeRunner := &fake.Runner{
+		JobEvents: []eventapi.JobEvent{{
+			Event:   eventapi.EventPlaybookOnStats,
+			Created: eventapi.EventTime{Time: eventTime},
+		}},
+	}
+	aor := &controller.AnsibleOperatorReconciler{
+		GVK:          gvk,
+		Runner:       fakeRunner,
+		Client:       c,
+		APIReader:    c,
+		ManageStatus: true,
+	}
+
+	if _, err := aor.Reconcile(context.TODO(), reconcile.Request{NamespacedName: nn}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if fakeRunner.LastSuccessfulRun != nil {
+		t.Fatalf("Unexpected last successful run for the first run: %v", fakeRunner.LastSuccessfulRun)
+	}
+
+	expected := map[string]interface{}{
+		"changed":    int64(0),
+		"failures":   int64(0),
+		"ok":         int64(0),
+		"skipped":    int64(0),
+		"completion": eventTime.Format("2006-01-02T15:04:05.99999999+00:00"),
+	}
+	// the result of the first run is kept across the second one failing, and
+	// given to both the second and the third run.
+	failedEvents := []eventapi.JobEvent{
+		{
+			Event:     eventapi.EventRunnerOnFailed,
+			Created:   eventapi.EventTime{Time: eventTime},
+			EventData: map[string]interface{}{"res": map[string]interface{}{"msg": "failure"}},
+		},
+		{Event: eventapi.EventPlaybookOnStats, Created: eventapi.EventTime{Time: eventTime}},
+	}
+	for _, events := range [][]eventapi.JobEvent{failedEvents, failedEvents} {
+		fakeRunner.JobEvents = events
+		if _, err := aor.Reconcile(context.TODO(), reconcile.Request{NamespacedName: nn}); err == nil {
+			t.Fatal("Expected the failed run to fail the reconcile")
+		}
+		if !reflect.DeepEqual(fakeRunner.LastSuccessfulRun, expected) {
+			t.Fatalf("Unexpected last successful run\nexpected: %v\nactual: %v", expected,
+				fakeRunner.LastSuccessfulRun)
+		}
+	}
+}
+
+// postingRunner posts outputs to the apiserver during the run with the token
+// of the run, as the tasks of a playbook do with the uri module.
+type postingRunner struct {
+	*fake.Runner
+	url     string
+	outputs string
+	status  int
+}
+
+func (r *postingRunner) Run(ctx context.Context, ident string, u *unstructured.Unstructured, kubeconfig, token string,
+	previousOutputs, lastSuccessfulRun map[string]interface{}) (runner.RunResult, error) {
+	if r.outputs != "" {
+		req, err := http.NewRequest(http.MethodPost, r.url+"/outputs/"+ident, strings.NewReader(r.outputs))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		resp.Body.Close()
+		r.status = resp.StatusCode
+	}
+	return r.Runner.Run(ctx, ident, u, kubeconfig, token, previousOutputs, lastSuccessfulRun)
+}
+
+func TestReconcilePostedOutputs(t *testing.T) {
+	// the apiserver collects the outputs the runs post.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+	tracker := outputs.NewTracker()
+	store := credentials.NewStore(time.Hour)
+	go func() {
+		_ = apiserver.Run(apiserver.Options{Address: "127.0.0.1", Port: port, Outputs: tracker,
+			Credentials: store})
+	}()
+	url := fmt.Sprintf("http://127.0.0.1:%d", port)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		resp, err := http.Post(url+"/outputs/unknown", "application/json", strings.NewReader("{}"))
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusUnauthorized {
+				t.Fatalf("Unexpected status %d for outputs posted without a token", resp.StatusCode)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for the apiserver: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	gvk := schema.GroupVersionKind{
+		Kind:    "Testing",
+		Group:   "operator-sdk",
+		Version: "v1beta1",
+	}
+	nn := types.NamespacedName{Name: "reconcile", Namespace: "default"}
+	cr := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"name":      nn.Name,
+				"namespace": nn.Namespace,
+			},
+			"apiVersion": "operator-sdk/v1beta1",
+			"kind":       "Testing",
+			"status": map[string]interface{}{
+				"outputs": map[string]interface{}{"revision": "v1", "stale": "yes"},
+			},
+		},
+	}
+	c := fakeclient.NewClientBuilder().WithStatusSubresource(cr).WithObjects(cr).Build()
+	statsEvent := eventapi.JobEvent{Event: eventapi.EventPlaybookOnStats}
+	aor := &controller.AnsibleOperatorReconciler{
+		GVK:          gvk,
+		Client:       c,
+		APIReader:    c,
+		OutputsStore: watches.OutputsStoreStatus,
+		Outputs:      tracker,
+		Credentials:  store,
+	}
+
+	// the first run posts its outputs, which are kept for the second run.
+	for _, tc := range []struct {
+		posted           string
+		expectedPrevious map[string]interface{}
+	}{
+		{
+			posted:           `{"revision": "v2", "stale": null}`,
+			expectedPrevious: map[string]interface{}{"revision": "v1", "stale": "yes"},
+		},
+		{
+			expectedPrevious: map[string]interface{}{"revision": "v2"},
+		},
+	} {
+		postingRunner := &postingRunner{Runner: &fake.Runner{JobEvents: []eventapi.JobEvent{statsEvent}},
+			url: url, outputs: tc.posted}
+		aor.Runner = postingRunner
+		if _, err := aor.Reconcile(context.TODO(), reconcile.Request{NamespacedName: nn}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if tc.posted != "" && postingRunner.status != http.StatusNoContent {
+			t.Fatalf("Unexpected status %d posting the outputs", postingRunner.status)
+		}
+		if !reflect.DeepEqual(postingRunner.PreviousOutputs, tc.expectedPrevious) {
+			t.Fatalf("Unexpected previous outputs\nexpected: %v\nactual: %v", tc.expectedPrevious,
+				postingRunner.PreviousOutputs)
+		}
+	}
+}
+
+// streamingRunner streams events to the reconcile, closing sent once all
+// were taken.
+type streamingRunner struct {
+	*fake.Runner
+	events []eventapi.JobEvent
+	sent   chan struct{}
+}
+
+type streamingResult struct {
+	events chan eventapi.JobEvent
+}
+
+func (r *streamingResult) Stdout() (string, error)          { return "", nil }
+func (r *streamingResult) Events() <-chan eventapi.JobEvent { return r.events }
+func (r *streamingResult) Wait() (runner.Outcome, error)    { return runner.Outcome{}, nil }
+
+func (r *streamingRunner) Run(context.Context, string, *unstructured.Unstructured, string, string,
+	map[string]interface{}, map[string]interface{}) (runner.RunResult, error) {
+	result := &streamingResult{events: make(chan eventapi.JobEvent)}
+	go func() {
+		for _, event := range r.events {
+			result.events <- event
+		}
+		close(result.events)
+		close(r.sent)
+	}()
+	return result, nil
+}
+
+// countingHandler counts the events it is given.
+type countingHandler struct {
+	count atomic.Int64
+}
+
+func (h *countingHandler) Handle(string, *unstructured.Unstructured, eventapi.JobEvent) {
+	h.count.Add(1)
+}
+
+func TestReconcileDrainsEvents(t *testing.T) {
+	gvk := schema.GroupVersionKind{
+		Kind:    "Testing",
+		Group:   "operator-sdk",
+		Version: "v1beta1",
+	}
+	nn := types.NamespacedName{Name: "reconcile", Namespace: "default"}
+	cr := &unstructured.Unstructured{}
+	cr.SetGroupVersionKind(gvk)
+	cr.SetName(nn.Name)
+	cr.SetNamespace(nn.Namespace)
+
+	// the reconcile returns on the requeue, before the rest of the events,
+	// more than the buffers of the event receiver hold.
+	jobEvents := []eventapi.JobEvent{{
+		Event: eventapi.EventRunnerOnOk,
+		EventData: map[string]interface{}{
+			"task_action": "operator_sdk.util.requeue_after",
+			"res":         map[string]interface{}{"period": "1m"},
+		},
+	}}
+	for i := 0; i < 3000; i++ {
+		jobEvents = append(jobEvents, eventapi.JobEvent{Event: eventapi.EventRunnerOnOk})
+	}
+	streaming := &streamingRunner{Runner: &fake.Runner{}, events: jobEvents, sent: make(chan struct{})}
+	handler := &countingHandler{}
+	c := getFakeClientFromObject(cr, true)
+	aor := &controller.AnsibleOperatorReconciler{
+		GVK:           gvk,
+		Runner:        streaming,
+		Client:        c,
+		APIReader:     c,
+		EventHandlers: []events.EventHandler{handler},
+	}
+	result, err := aor.Reconcile(context.TODO(), reconcile.Request{NamespacedName: nn})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.RequeueAfter != time.Minute {
+		t.Fatalf("Unexpected requeue after %v", result.RequeueAfter)
+	}
+	select {
+	case <-streaming.sent:
+	case <-time.After(10 * time.Second):
+		t.Fatal("The events left after the reconcile returned were not drained")
+	}
+	// the drained events are still given to the handlers.
+	deadline := time.Now().Add(10 * time.Second)
+	for handler.count.Load() != int64(len(jobEvents)) {
+		if time.Now().After(deadline) {
+			t.Fatalf("The handlers were given %d events, expected %d", handler.count.Load(), len(jobEvents))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+// recordingRunner records objects to the inventory of the run, as the proxy
+// does for the objects a run creates or updates.
+type recordingRunner struct {
+	*fake.Runner
+	inventory *inventory.Recorder
+	objects   []inventory.Object
+}
+
+func (r *recordingRunner) Run(ctx context.Context, ident string, u *unstructured.Unstructured, kubeconfig, token string,
+	previousOutputs, lastSuccessfulRun map[string]interface{}) (runner.RunResult, error) {
+	for _, o := range r.objects {
+		r.inventory.Record(ident, o)
+	}
+	return r.Runner.Run(ctx, ident, u, kubeconfig, token, previousOutputs, lastSuccessfulRun)
+}
+
+func TestReconcilePrune(t *testing.T) {
+	gvk := schema.GroupVersionKind{
+		Kind:    "Testing",
+		Group:   "operator-sdk",
+		Version: "v1beta1",
+	}
+	nn := types.NamespacedName{Name: "reconcile", Namespace: "default"}
+	newCR := func() *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(gvk)
+		u.SetName(nn.Name)
+		u.SetNamespace(nn.Namespace)
+		u.SetUID("cr-uid")
+		return u
+	}
+	configMap := func(name string, owned bool) *v1.ConfigMap {
+		cm := &v1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: nn.Namespace}}
+		if owned {
+			cm.OwnerReferences = []metav1.OwnerReference{{APIVersion: "operator-sdk/v1beta1", Kind: "Testing",
+				Name: nn.Name, UID: "cr-uid"}}
+		}
+		return cm
+	}
+	object := func(kind, name string) inventory.Object {
+		return inventory.Object{APIVersion: "v1", Kind: kind, Namespace: nn.Namespace, Name: name}
+	}
+	previous, err := json.Marshal([]inventory.Object{object("ConfigMap", "adopted"), object("ConfigMap", "kept"),
+		object("ConfigMap", "stale"), object("Secret", "other")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	statsEvent := eventapi.JobEvent{Event: eventapi.EventPlaybookOnStats}
+	requeueEvent := eventapi.JobEvent{
+		Event: eventapi.EventRunnerOnOk,
+		EventData: map[string]interface{}{
+			"task_action": "operator_sdk.util.requeue_after",
+			"res":         map[string]interface{}{"period": "1m"},
+		},
+	}
+
+	testCases := []struct {
+		name              string
+		rc                int
+		requeue           bool
+		inventoryNotOwned bool
+		impersonate       string
+		conflict          bool
+		expectedErr       bool
+		expectedRemaining []string
+		expectedInventory []inventory.Object
+		expectedDeleters  []string
+	}{
+		{
+			name:              "successful run",
+			expectedRemaining: []string{"adopted", "kept"},
+			expectedInventory: []inventory.Object{object("ConfigMap", "created"), object("ConfigMap", "kept")},
+			expectedDeleters:  []string{""},
+		},
+		{
+			name:              "failed run",
+			rc:                1,
+			expectedRemaining: []string{"adopted", "kept", "stale"},
+			expectedInventory: []inventory.Object{object("ConfigMap", "adopted"), object("ConfigMap", "created"),
+				object("ConfigMap", "kept"), object("ConfigMap", "stale")},
+		},
+		{
+			// the run is pruned once finished, after the reconcile returned.
+			name:              "requeued run",
+			requeue:           true,
+			expectedRemaining: []string{"adopted", "kept"},
+			expectedInventory: []inventory.Object{object("ConfigMap", "created"), object("ConfigMap", "kept")},
+			expectedDeleters:  []string{""},
+		},
+		{
+			// the objects are pruned as the ServiceAccount the run made its
+			// API calls as.
+			name:              "impersonating run",
+			impersonate:       "runner",
+			expectedRemaining: []string{"adopted", "kept"},
+			expectedInventory: []inventory.Object{object("ConfigMap", "created"), object("ConfigMap", "kept")},
+			expectedDeleters:  []string{"system:serviceaccount:default:runner"},
+		},
+		{
+			// the inventory updated by another run meanwhile is read again.
+			name:              "conflicting inventory update",
+			rc:                1,
+			conflict:          true,
+			expectedRemaining: []string{"adopted", "kept", "stale"},
+			expectedInventory: []inventory.Object{object("ConfigMap", "adopted"), object("ConfigMap", "concurrent"),
+				object("ConfigMap", "created"), object("ConfigMap", "kept"), object("ConfigMap", "stale")},
+		},
+		{
+			// a ConfigMap of the user with the name of the inventory is
+			// left alone, and nothing is pruned.
+			name:              "inventory not owned",
+			inventoryNotOwned: true,
+			expectedErr:       true,
+			expectedRemaining: []string{"adopted", "kept", "stale"},
+			expectedInventory: []inventory.Object{object("ConfigMap", "adopted"), object("ConfigMap", "kept"),
+				object("ConfigMap", "stale"), object("Secret", "other")},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cr := newCR()
+			inventoryConfigMap := configMap("reconcile-testing.operator-sdk-ansible-inventory", !tc.inventoryNotOwned)
+			inventoryConfigMap.Data = map[string]string{"inventory": string(previous)}
+			key := types.NamespacedName{Name: inventoryConfigMap.Name, Namespace: nn.Namespace}
+			c := fakeclient.NewClientBuilder().WithStatusSubresource(cr).WithObjects(cr,
+				configMap("adopted", false), configMap("kept", true), configMap("stale", true),
+				inventoryConfigMap).Build()
+
+			// the users the objects are deleted as.
+			var mutex sync.Mutex
+			var deleters []string
+			deleteAs := func(user string) func(context.Context, client.WithWatch, client.Object,
+				...client.DeleteOption) error {
+				return func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+					mutex.Lock()
+					deleters = append(deleters, user)
+					mutex.Unlock()
+					return c.Delete(ctx, obj, opts...)
+				}
+			}
+			conflicted := false
+			operatorClient := interceptor.NewClient(c, interceptor.Funcs{
+				Delete: deleteAs(""),
+				Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+					// another run adds an object to the inventory before
+					// the first update of the inventory.
+					if tc.conflict && !conflicted && obj.GetName() == key.Name {
+						conflicted = true
+						cm := &v1.ConfigMap{}
+						if err := c.Get(ctx, key, cm); err != nil {
+							return err
+						}
+						var objects []inventory.Object
+						if err := json.Unmarshal([]byte(cm.Data["inventory"]), &objects); err != nil {
+							return err
+						}
+						data, err := json.Marshal(append(objects, object("ConfigMap", "concurrent")))
+						if err != nil {
+							return err
+						}
+						cm.Data["inventory"] = string(data)
+						if err := c.Update(ctx, cm); err != nil {
+							return err
+						}
+					}
+					return c.Update(ctx, obj, opts...)
+				},
+			})
+			impersonate, err := impersonation.New(tc.impersonate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			recorder := inventory.NewRecorder()
+			events := []eventapi.JobEvent{statsEvent}
+			if tc.requeue {
+				events = append([]eventapi.JobEvent{requeueEvent}, events...)
+			}
+			aor := &controller.AnsibleOperatorReconciler{
+				GVK: gvk,
+				Runner: &recordingRunner{
+					Runner:    &fake.Runner{JobEvents: events, RC: tc.rc},
+					inventory: recorder,
+					objects:   []inventory.Object{object("ConfigMap", "kept"), object("ConfigMap", "created")},
+				},
+				Client:      operatorClient,
+				APIReader:   c,
+				Impersonate: impersonate,
+				ImpersonatedClient: func(user string) (client.Client, error) {
+					return interceptor.NewClient(c, interceptor.Funcs{Delete: deleteAs(user)}), nil
+				},
+				Inventory:  recorder,
+				PruneKinds: []schema.GroupKind{{Kind: "ConfigMap"}},
+			}
+			_, err = aor.Reconcile(context.TODO(), reconcile.Request{NamespacedName: nn})
+			// the failed run is requeued with an error too.
+			if tc.expectedErr && err == nil {
+				t.Fatal("Expected an error")
+			}
+
+			// the requeued run is pruned in the background.
+			var objects []inventory.Object
+			for start := time.Now(); ; time.Sleep(10 * time.Millisecond) {
+				cm := &v1.ConfigMap{}
+				if err := c.Get(context.TODO(), key, cm); err != nil {
+					t.Fatalf("Failed to get inventory: %v", err)
+				}
+				objects = nil
+				if err := json.Unmarshal([]byte(cm.Data["inventory"]), &objects); err != nil {
+					t.Fatal(err)
+				}
+				if reflect.DeepEqual(objects, tc.expectedInventory) || time.Since(start) > 5*time.Second {
+					break
+				}
+			}
+			if !reflect.DeepEqual(objects, tc.expectedInventory) {
+				t.Fatalf("Unexpected inventory\nexpected: %v\nactual: %v", tc.expectedInventory, objects)
+			}
+
+			for _, name := range []string{"adopted", "kept", "stale"} {
+				err := c.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: nn.Namespace}, &v1.ConfigMap{})
+				remaining := err == nil
+				expected := false
+				for _, n := range tc.expectedRemaining {
+					expected = expected || n == name
+				}
+				if remaining != expected {
+					t.Fatalf("Unexpected ConfigMap %s remaining %t: %v", name, remaining, err)
+				}
+			}
+			mutex.Lock()
+			defer mutex.Unlock()
+			if !reflect.DeepEqual(deleters, tc.expectedDeleters) {
+				t.Fatalf("Unexpected users deleting\nexpected: %q\nactual: %q", tc.expectedDeleters, deleters)
+			}
+		})
+	}
+}
@@ -153,21 +153,28 @@ func createConditionFromMap(cm map[string]interface{}) Condition {
 
 // Status - The status for custom resources managed by the operator-sdk.
 type Status struct {
-	Conditions   []Condition            `json:"conditions"`
-	CustomStatus map[string]interface{} `json:"-"`
+	Conditions []Condition `json:"conditions"`
+	// LastSuccessfulRun is the result of the last successful run, kept
+	// across the runs that fail.
+	LastSuccessfulRun *AnsibleResult         `json:"lastSuccessfulRun,omitempty"`
+	CustomStatus      map[string]interface{} `json:"-"`
 }
 
 // CreateFromMap - create a status from the map
 func CreateFromMap(statusMap map[string]interface{}) Status {
 	customStatus := make(map[string]interface{})
 	for key, value := range statusMap {
-		if key != "conditions" {
+		if key != "conditions" && key != "lastSuccessfulRun" {
 			customStatus[key] = value
 		}
 	}
+	var lastSuccessfulRun *AnsibleResult
+	if lsr, ok := statusMap["lastSuccessfulRun"].(map[string]interface{}); ok {
+		lastSuccessfulRun = NewAnsibleResultFromMap(lsr)
+	}
 	conditionsInterface, ok := statusMap["conditions"].([]interface{})
 	if !ok {
-		return Status{Conditions: []Condition{}, CustomStatus: customStatus}
+		return Status{Conditions: []Condition{}, LastSuccessfulRun: lastSuccessfulRun, CustomStatus: customStatus}
 	}
 	conditions := []Condition{}
 	for _, ci := range conditionsInterface {
@@ -178,7 +185,7 @@ func CreateFromMap(statusMap map[string]interface{}) Status {
 		}
 		conditions = append(conditions, createConditionFromMap(cm))
 	}
-	return Status{Conditions: conditions, CustomStatus: customStatus}
+	return Status{Conditions: conditions, LastSuccessfulRun: lastSuccessfulRun, CustomStatus: customStatus}
 }
 
 // GetJSONMap - gets the map value for the status object.
@@ -0,0 +1,179 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+// OverflowPolicy - what a Dispatcher does with an event when the queue of a
+// handler is full.
+type OverflowPolicy string
+
+const (
+	// OverflowBlock - waits for the handler to make room, which holds back
+	// the job.
+	OverflowBlock OverflowPolicy = "block"
+	// OverflowDropOldest - drops the oldest event in the queue to make room.
+	OverflowDropOldest OverflowPolicy = "drop-oldest"
+	// OverflowDropNewest - drops the event being dispatched.
+	OverflowDropNewest OverflowPolicy = "drop-newest"
+
+	// DefaultBufferSize - the number of events queued for each handler by
+	// default.
+	DefaultBufferSize = 100
+
+	// slowHandlerThreshold is how long a handler may take to handle an event
+	// before it is reported as slow.
+	slowHandlerThreshold = time.Second
+)
+
+// DispatchOptions - how the events of a job are queued for each handler.
+type DispatchOptions struct {
+	// BufferSize is the number of events queued for each handler. Defaults to
+	// DefaultBufferSize when zero.
+	BufferSize int
+	// Overflow is what happens to an event when a queue is full. Defaults to
+	// OverflowBlock when empty.
+	Overflow OverflowPolicy
+}
+
+// Validate - returns an error if the options are invalid.
+func (o DispatchOptions) Validate() error {
+	if o.BufferSize < 0 {
+		return fmt.Errorf("event handler buffer size must not be negative, got %d", o.BufferSize)
+	}
+	switch o.Overflow {
+	case "", OverflowBlock, OverflowDropOldest, OverflowDropNewest:
+		return nil
+	default:
+		return fmt.Errorf("event handler overflow policy must be one of %s, %s or %s, got %q",
+			OverflowBlock, OverflowDropOldest, OverflowDropNewest, o.Overflow)
+	}
+}
+
+// Dispatcher - passes the events of a job to each handler, in the order they
+// were received. Each handler has its own queue, so that a slow handler only
+// holds back the others once its queue is full and the overflow policy is to
+// block.
+type Dispatcher struct {
+	queues   []*handlerQueue
+	overflow OverflowPolicy
+	wg       sync.WaitGroup
+	once     sync.Once
+}
+
+type handlerQueue struct {
+	handler EventHandler
+	events  chan eventapi.JobEvent
+	// dropped counts the events dropped because the queue was full.
+	dropped int
+}
+
+// NewDispatcher - starts dispatching the events of the job with the given
+// ident to the handlers. The dispatcher must be flushed.
+func NewDispatcher(ident string, u *unstructured.Unstructured, handlers []EventHandler,
+	options DispatchOptions) *Dispatcher {
+	size := options.BufferSize
+	if size == 0 {
+		size = DefaultBufferSize
+	}
+	d := &Dispatcher{overflow: options.Overflow}
+	if d.overflow == "" {
+		d.overflow = OverflowBlock
+	}
+	logger := logf.Log.WithName("event_dispatcher").WithValues(
+		"name", u.GetName(),
+		"namespace", u.GetNamespace(),
+		"gvk", u.GroupVersionKind().String(),
+		"job", ident,
+	)
+	// the caller may update the resource while the events are handled.
+	u = u.DeepCopy()
+	for _, h := range handlers {
+		q := &handlerQueue{handler: h, events: make(chan eventapi.JobEvent, size)}
+		d.queues = append(d.queues, q)
+		d.wg.Add(1)
+		go func() {
+			defer d.wg.Done()
+			q.run(ident, u, logger.WithValues("handler", reflect.TypeOf(h).String()))
+		}()
+	}
+	return d
+}
+
+// Dispatch - queues the event for each handler.
+func (d *Dispatcher) Dispatch(e eventapi.JobEvent) {
+	for _, q := range d.queues {
+		switch d.overflow {
+		case OverflowDropNewest:
+			select {
+			case q.events <- e:
+			default:
+				q.dropped++
+			}
+		case OverflowDropOldest:
+			for sent := false; !sent; {
+				select {
+				case q.events <- e:
+					sent = true
+				default:
+					select {
+					case <-q.events:
+						q.dropped++
+					default:
+					}
+				}
+			}
+		default:
+			q.events <- e
+		}
+	}
+}
+
+// Flush - waits for the handlers to handle every event dispatched. No event
+// may be dispatched after a flush.
+func (d *Dispatcher) Flush() {
+	d.once.Do(func() {
+		for _, q := range d.queues {
+			close(q.events)
+		}
+		d.wg.Wait()
+	})
+}
+
+func (q *handlerQueue) run(ident string, u *unstructured.Unstructured, logger logr.Logger) {
+	for e := range q.events {
+		start := time.Now()
+		q.handler.Handle(ident, u, e)
+		if d := time.Since(start); d > slowHandlerThreshold {
+			logger.Info("Slow event handler", "event_type", e.Event, "duration", d.String())
+		}
+	}
+	// dropped is only read once the queue is closed, so Dispatch is done
+	// with it.
+	if q.dropped > 0 {
+		logger.Info("Dropped events because the handler queue was full", "dropped", q.dropped)
+	}
+}
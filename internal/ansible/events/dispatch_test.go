@@ -0,0 +1,117 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+// recordingHandler records the counter of the events it handles, after
+// waiting for release to be closed if set.
+type recordingHandler struct {
+	release chan struct{}
+	mu      sync.Mutex
+	handled []int
+}
+
+func (h *recordingHandler) Handle(_ string, _ *unstructured.Unstructured, e eventapi.JobEvent) {
+	if h.release != nil {
+		<-h.release
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handled = append(h.handled, e.Counter)
+}
+
+func TestDispatcher(t *testing.T) {
+	testCases := []struct {
+		name    string
+		options DispatchOptions
+		// expected holds the events the slow handler may have handled, as it
+		// may or may not have taken the first event before its queue filled up.
+		expected [][]int
+	}{
+		{
+			name:     "block",
+			options:  DispatchOptions{BufferSize: 2},
+			expected: [][]int{{1, 2, 3, 4, 5}},
+		},
+		{
+			name:     "drop newest",
+			options:  DispatchOptions{BufferSize: 2, Overflow: OverflowDropNewest},
+			expected: [][]int{{1, 2}, {1, 2, 3}},
+		},
+		{
+			name:     "drop oldest",
+			options:  DispatchOptions{BufferSize: 2, Overflow: OverflowDropOldest},
+			expected: [][]int{{4, 5}, {1, 4, 5}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			slow := &recordingHandler{release: make(chan struct{})}
+			fast := &recordingHandler{}
+			d := NewDispatcher("1", &unstructured.Unstructured{}, []EventHandler{slow, fast}, tc.options)
+
+			dispatched := make(chan struct{})
+			go func() {
+				defer close(dispatched)
+				for i := 1; i <= 5; i++ {
+					d.Dispatch(eventapi.JobEvent{Counter: i})
+				}
+			}()
+			blocking := tc.options.Overflow == ""
+			if blocking {
+				close(slow.release)
+				<-dispatched
+			} else {
+				// dropping events never waits for the slow handler.
+				<-dispatched
+				close(slow.release)
+			}
+			d.Flush()
+
+			// the fast handler may drop events too, unless blocking.
+			if expected := []int{1, 2, 3, 4, 5}; blocking && !reflect.DeepEqual(fast.handled, expected) {
+				t.Fatalf("Unexpected events handled by the fast handler %v, expected %v", fast.handled, expected)
+			}
+			for _, expected := range tc.expected {
+				if reflect.DeepEqual(slow.handled, expected) {
+					return
+				}
+			}
+			t.Fatalf("Unexpected events handled by the slow handler %v, expected one of %v", slow.handled, tc.expected)
+		})
+	}
+}
+
+func TestDispatchOptionsValidate(t *testing.T) {
+	for _, o := range []DispatchOptions{{}, {BufferSize: 10, Overflow: OverflowDropOldest}} {
+		if err := o.Validate(); err != nil {
+			t.Fatalf("Unexpected error for %+v: %v", o, err)
+		}
+	}
+	for _, o := range []DispatchOptions{{BufferSize: -1}, {Overflow: "drop-all"}} {
+		if err := o.Validate(); err == nil {
+			t.Fatalf("Expected an error for %+v", o)
+		}
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"strconv"
 	"sync"
 
+	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -41,14 +42,38 @@ const (
 	Nothing
 )
 
+// LogFormat - how ansible output is logged.
+type LogFormat string
+
+const (
+	// TextLogFormat - prints the stdout of ansible in framed blocks.
+	TextLogFormat LogFormat = "text"
+
+	// JSONLogFormat - logs a structured record for every task event through
+	// the logger, with the stdout of ansible as a field.
+	JSONLogFormat LogFormat = "json"
+)
+
+// taskStatuses - the status of a task, by the event reporting its result.
+var taskStatuses = map[string]string{
+	eventapi.EventRunnerOnOk:          "ok",
+	eventapi.EventRunnerOnFailed:      "failed",
+	eventapi.EventRunnerOnSkipped:     "skipped",
+	eventapi.EventRunnerOnUnreachable: "unreachable",
+	eventapi.EventRunnerItemOnOk:      "ok",
+	eventapi.EventRunnerItemOnFailed:  "failed",
+	eventapi.EventRunnerItemOnSkipped: "skipped",
+}
+
 // EventHandler - knows how to handle job events.
 type EventHandler interface {
 	Handle(string, *unstructured.Unstructured, eventapi.JobEvent)
 }
 
 type loggingEventHandler struct {
-	LogLevel LogLevel
-	mux      *sync.Mutex
+	LogLevel  LogLevel
+	LogFormat LogFormat
+	mux       *sync.Mutex
 }
 
 func (l loggingEventHandler) Handle(ident string, u *unstructured.Unstructured, e eventapi.JobEvent) {
@@ -66,6 +91,11 @@ func (l loggingEventHandler) Handle(ident string, u *unstructured.Unstructured,
 
 	verbosity := GetVerbosity(u, e, ident)
 
+	if l.LogFormat == JSONLogFormat {
+		l.logJSON(logger, e, verbosity)
+		return
+	}
+
 	// logger only the following for the 'Tasks' LogLevel
 	if l.LogLevel == Tasks {
 		t, ok := e.EventData["task"]
@@ -137,11 +167,70 @@ func (l loggingEventHandler) logAnsibleStdOut(e eventapi.JobEvent) {
 	}
 }
 
-// NewLoggingEventHandler - Creates a Logging Event Handler to log events.
-func NewLoggingEventHandler(l LogLevel) EventHandler {
+// logJSON logs a structured record for the failed and changed results of the
+// tasks and the results of debug tasks with the 'Tasks' LogLevel, and for
+// every event with the 'Everything' LogLevel. As in text, every event of a
+// task is logged with the 'Tasks' LogLevel if the verbosity is raised.
+func (l loggingEventHandler) logJSON(logger logr.Logger, e eventapi.JobEvent, verbosity int) {
+	_, isTask := e.EventData["task"]
+	verbose := l.LogLevel == Everything || (isTask && verbosity > 0)
+	status, ok := taskStatuses[e.Event]
+	if !ok {
+		if verbose {
+			kvs := []interface{}{"event_data", e.EventData}
+			if len(e.StdOut) > 0 {
+				kvs = append(kvs, "stdout", e.StdOut)
+			}
+			logger.Info("Ansible event", kvs...)
+		}
+		return
+	}
+	if status == "failed" && e.IgnoreError() {
+		status = "ignored"
+	}
+
+	changed := false
+	if res, ok := e.EventData["res"].(map[string]interface{}); ok {
+		changed, _ = res["changed"].(bool)
+	}
+	debugAction := e.EventData["task_action"] == eventapi.TaskActionDebug
+	if !verbose && status != "failed" && !changed && !(debugAction && status == "ok") {
+		return
+	}
+	kvs := []interface{}{
+		"task", e.EventData["task"],
+		"action", e.EventData["task_action"],
+		"role", e.EventData["role"],
+		"host", e.EventData["host"],
+		"status", status,
+		"changed", changed,
+	}
+	if debugAction {
+		kvs = append(kvs, "task_args", e.EventData["task_args"])
+	}
+	if duration, ok := e.EventData["duration"].(float64); ok {
+		kvs = append(kvs, "duration", duration)
+	}
+	if len(e.StdOut) > 0 {
+		kvs = append(kvs, "stdout", e.StdOut)
+	}
+	if status == "failed" {
+		if taskPath, ok := e.EventData["task_path"]; ok {
+			kvs = append(kvs, "task_path", taskPath)
+		}
+		logger.Error(errors.New("[playbook task failed]"), "Ansible task", kvs...)
+		return
+	}
+	logger.Info("Ansible task", kvs...)
+}
+
+// NewLoggingEventHandler - Creates a Logging Event Handler to log events in
+// the given format, text if empty.
+func NewLoggingEventHandler(l LogLevel, f LogFormat) EventHandler {
 	return loggingEventHandler{
-		LogLevel: l,
-		mux:      &sync.Mutex{},
+		LogLevel:  l,
+		LogFormat: f,
+		mux:       &sync.Mutex{},
 	}
 }
 
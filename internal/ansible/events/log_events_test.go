@@ -0,0 +1,220 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+func TestLogJSON(t *testing.T) {
+	testCases := []struct {
+		name      string
+		level     LogLevel
+		verbosity int
+		event     eventapi.JobEvent
+		expected  []map[string]interface{}
+	}{
+		{
+			name:  "changed task",
+			level: Tasks,
+			event: eventapi.JobEvent{
+				Event:  eventapi.EventRunnerOnOk,
+				StdOut: "changed: [localhost]\nline two",
+				EventData: map[string]interface{}{
+					"task":        "create deployment",
+					"task_action": "kubernetes.core.k8s",
+					"role":        "memcached",
+					"host":        "localhost",
+					"duration":    1.5,
+					"res":         map[string]interface{}{"changed": true},
+				},
+			},
+			expected: []map[string]interface{}{{
+				"msg":      "Ansible task",
+				"task":     "create deployment",
+				"action":   "kubernetes.core.k8s",
+				"role":     "memcached",
+				"host":     "localhost",
+				"status":   "ok",
+				"changed":  true,
+				"duration": 1.5,
+				"stdout":   "changed: [localhost]\nline two",
+			}},
+		},
+		{
+			name:  "unchanged task with tasks level",
+			level: Tasks,
+			event: eventapi.JobEvent{
+				Event:     eventapi.EventRunnerOnOk,
+				EventData: map[string]interface{}{"task": "get deployment", "res": map[string]interface{}{}},
+			},
+		},
+		{
+			name:      "unchanged task with tasks level and verbosity",
+			level:     Tasks,
+			verbosity: 2,
+			event: eventapi.JobEvent{
+				Event:     eventapi.EventRunnerOnOk,
+				StdOut:    "ok: [localhost]",
+				EventData: map[string]interface{}{"task": "get deployment", "res": map[string]interface{}{}},
+			},
+			expected: []map[string]interface{}{{
+				"msg":     "Ansible task",
+				"task":    "get deployment",
+				"action":  nil,
+				"role":    nil,
+				"host":    nil,
+				"status":  "ok",
+				"changed": false,
+				"stdout":  "ok: [localhost]",
+			}},
+		},
+		{
+			name:      "task start with tasks level and verbosity",
+			level:     Tasks,
+			verbosity: 1,
+			event: eventapi.JobEvent{
+				Event:     eventapi.EventPlaybookOnTaskStart,
+				StdOut:    "TASK [probe]",
+				EventData: map[string]interface{}{"task": "probe"},
+			},
+			expected: []map[string]interface{}{{
+				"msg":        "Ansible event",
+				"event_data": map[string]interface{}{"task": "probe"},
+				"stdout":     "TASK [probe]",
+			}},
+		},
+		{
+			name:  "debug task with tasks level",
+			level: Tasks,
+			event: eventapi.JobEvent{
+				Event: eventapi.EventRunnerOnOk,
+				EventData: map[string]interface{}{
+					"task":        "show replicas",
+					"task_action": eventapi.TaskActionDebug,
+					"task_args":   "msg=3",
+					"res":         map[string]interface{}{},
+				},
+			},
+			expected: []map[string]interface{}{{
+				"msg":       "Ansible task",
+				"task":      "show replicas",
+				"action":    eventapi.TaskActionDebug,
+				"role":      nil,
+				"host":      nil,
+				"status":    "ok",
+				"changed":   false,
+				"task_args": "msg=3",
+			}},
+		},
+		{
+			name:  "ignored failure with tasks level",
+			level: Tasks,
+			event: eventapi.JobEvent{
+				Event:     eventapi.EventRunnerOnFailed,
+				EventData: map[string]interface{}{"task": "probe", "ignore_errors": true},
+			},
+		},
+		{
+			name:  "failed task with tasks level",
+			level: Tasks,
+			event: eventapi.JobEvent{
+				Event: eventapi.EventRunnerOnFailed,
+				EventData: map[string]interface{}{
+					"task":      "create deployment",
+					"host":      "localhost",
+					"task_path": "/opt/ansible/roles/memcached/tasks/main.yml:1",
+				},
+			},
+			expected: []map[string]interface{}{{
+				"msg":       "Ansible task",
+				"error":     "[playbook task failed]",
+				"task":      "create deployment",
+				"action":    nil,
+				"role":      nil,
+				"host":      "localhost",
+				"status":    "failed",
+				"changed":   false,
+				"task_path": "/opt/ansible/roles/memcached/tasks/main.yml:1",
+			}},
+		},
+		{
+			name:  "ignored failure",
+			level: Everything,
+			event: eventapi.JobEvent{
+				Event: eventapi.EventRunnerOnFailed,
+				EventData: map[string]interface{}{
+					"task":          "probe",
+					"host":          "localhost",
+					"ignore_errors": true,
+				},
+			},
+			expected: []map[string]interface{}{{
+				"msg":     "Ansible task",
+				"task":    "probe",
+				"action":  nil,
+				"role":    nil,
+				"host":    "localhost",
+				"status":  "ignored",
+				"changed": false,
+			}},
+		},
+		{
+			name:  "other event with tasks level",
+			level: Tasks,
+			event: eventapi.JobEvent{Event: eventapi.EventPlaybookOnTaskStart},
+		},
+		{
+			name:  "other event with everything level",
+			level: Everything,
+			event: eventapi.JobEvent{
+				Event:     eventapi.EventPlaybookOnTaskStart,
+				StdOut:    "TASK [probe]",
+				EventData: map[string]interface{}{"name": "probe"},
+			},
+			expected: []map[string]interface{}{{
+				"msg":        "Ansible event",
+				"event_data": map[string]interface{}{"name": "probe"},
+				"stdout":     "TASK [probe]",
+			}},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var records []map[string]interface{}
+			logger := funcr.NewJSON(func(obj string) {
+				record := map[string]interface{}{}
+				if err := json.Unmarshal([]byte(obj), &record); err != nil {
+					t.Fatalf("Unable to parse log record %s: %v", obj, err)
+				}
+				delete(record, "level")
+				delete(record, "logger")
+				records = append(records, record)
+			}, funcr.Options{})
+
+			l := NewLoggingEventHandler(tc.level, JSONLogFormat).(loggingEventHandler)
+			l.logJSON(logger, tc.event, tc.verbosity)
+			if !reflect.DeepEqual(records, tc.expected) {
+				t.Fatalf("Unexpected records\n%v\nexpected\n%v", records, tc.expected)
+			}
+		})
+	}
+}
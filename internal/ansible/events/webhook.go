@@ -0,0 +1,297 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+const (
+	// CloudEventTypePrefix - prefix of the type of the CloudEvents sent for
+	// ansible events, followed by the type of the ansible event.
+	CloudEventTypePrefix = "io.operatorframework.ansible."
+
+	// cloudEventsBatchContentType is the content type of a batch of
+	// CloudEvents in the structured JSON format.
+	cloudEventsBatchContentType = "application/cloudevents-batch+json"
+
+	// webhookQueueSize is the number of events waiting to be sent, beyond
+	// which events are dropped rather than holding back the runs.
+	webhookQueueSize = 1000
+
+	// webhookFinalFlushTimeout is how long the events still queued when the
+	// handler stops may take to be sent.
+	webhookFinalFlushTimeout = 10 * time.Second
+)
+
+// DefaultWebhookEventTypes - the ansible events forwarded by default: task
+// failures, and the stats sent when a run completes.
+var DefaultWebhookEventTypes = []string{eventapi.EventRunnerOnFailed, eventapi.EventPlaybookOnStats}
+
+// WebhookOptions - configures a handler forwarding events to a webhook.
+type WebhookOptions struct {
+	// URL is the endpoint the events are posted to.
+	URL string
+	// Source is the source of the CloudEvents. Defaults to "ansible-operator".
+	Source string
+	// EventTypes are the types of ansible events forwarded. Defaults to
+	// DefaultWebhookEventTypes.
+	EventTypes []string
+	// GVKs limits the events forwarded to the runs of these kinds. Every
+	// kind is forwarded if empty.
+	GVKs []schema.GroupVersionKind
+	// BatchSize is the number of events posted at most in a single request.
+	// Defaults to 10.
+	BatchSize int
+	// FlushInterval is how long an event may wait for its batch to fill up.
+	// Defaults to 5s.
+	FlushInterval time.Duration
+	// MaxRetries is the number of times a batch is posted again after
+	// failing. Zero disables the retries.
+	MaxRetries int
+	// Client is the client the events are posted with. Defaults to a client
+	// with a 10s timeout.
+	Client *http.Client
+}
+
+// CloudEvent - an ansible event, in the structured JSON format of CloudEvents.
+// https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/formats/json-format.md
+type CloudEvent struct {
+	SpecVersion     string         `json:"specversion"`
+	ID              string         `json:"id"`
+	Source          string         `json:"source"`
+	Type            string         `json:"type"`
+	Subject         string         `json:"subject,omitempty"`
+	Time            time.Time      `json:"time"`
+	DataContentType string         `json:"datacontenttype"`
+	Data            CloudEventData `json:"data"`
+}
+
+// CloudEventData - the data of a CloudEvent: the ansible event, and the job
+// and custom resource it belongs to.
+type CloudEventData struct {
+	Job        string            `json:"job"`
+	APIVersion string            `json:"apiVersion"`
+	Kind       string            `json:"kind"`
+	Name       string            `json:"name"`
+	Namespace  string            `json:"namespace,omitempty"`
+	Event      eventapi.JobEvent `json:"event"`
+}
+
+// WebhookHandler - an EventHandler forwarding the selected events to a
+// webhook as CloudEvents, in batches. The events are sent while the handler
+// is started, e.g. by adding it to the manager.
+type WebhookHandler struct {
+	options    WebhookOptions
+	eventTypes map[string]bool
+	gvks       map[schema.GroupVersionKind]bool
+	queue      chan CloudEvent
+	logger     logr.Logger
+}
+
+// NewWebhookHandler - creates a handler forwarding events to the webhook
+// configured by options.
+func NewWebhookHandler(options WebhookOptions) (*WebhookHandler, error) {
+	u, err := url.Parse(options.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event webhook URL: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("event webhook URL %q must be http or https", options.URL)
+	}
+	if options.BatchSize < 0 || options.FlushInterval < 0 || options.MaxRetries < 0 {
+		return nil, errors.New("event webhook batch size, flush interval and retries must not be negative")
+	}
+	if options.Source == "" {
+		options.Source = "ansible-operator"
+	}
+	if len(options.EventTypes) == 0 {
+		options.EventTypes = DefaultWebhookEventTypes
+	}
+	if options.BatchSize == 0 {
+		options.BatchSize = 10
+	}
+	if options.FlushInterval == 0 {
+		options.FlushInterval = 5 * time.Second
+	}
+	if options.Client == nil {
+		options.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	h := &WebhookHandler{
+		options:    options,
+		eventTypes: map[string]bool{},
+		gvks:       map[schema.GroupVersionKind]bool{},
+		queue:      make(chan CloudEvent, webhookQueueSize),
+		logger:     logf.Log.WithName("webhook_event_handler").WithValues("url", u.Redacted()),
+	}
+	for _, t := range options.EventTypes {
+		h.eventTypes[t] = true
+	}
+	for _, gvk := range options.GVKs {
+		h.gvks[gvk] = true
+	}
+	return h, nil
+}
+
+// Handle - queues the event to be sent, if selected.
+func (h *WebhookHandler) Handle(ident string, u *unstructured.Unstructured, e eventapi.JobEvent) {
+	if !h.eventTypes[e.Event] {
+		return
+	}
+	gvk := u.GroupVersionKind()
+	if len(h.gvks) > 0 && !h.gvks[gvk] {
+		return
+	}
+	eventTime := e.Created.Time
+	if eventTime.IsZero() {
+		eventTime = time.Now()
+	}
+	subject := u.GetName()
+	if u.GetNamespace() != "" {
+		subject = u.GetNamespace() + "/" + subject
+	}
+	ce := CloudEvent{
+		SpecVersion:     "1.0",
+		ID:              e.UUID,
+		Source:          h.options.Source,
+		Type:            CloudEventTypePrefix + e.Event,
+		Subject:         subject,
+		Time:            eventTime.UTC(),
+		DataContentType: "application/json",
+		Data: CloudEventData{
+			Job:        ident,
+			APIVersion: gvk.GroupVersion().String(),
+			Kind:       gvk.Kind,
+			Name:       u.GetName(),
+			Namespace:  u.GetNamespace(),
+			Event:      e,
+		},
+	}
+	select {
+	case h.queue <- ce:
+	default:
+		h.logger.Info("Dropping event, too many events are waiting to be sent", "job", ident,
+			"event_type", e.Event)
+	}
+}
+
+// Start - sends the queued events until ctx is done, then sends the events
+// still queued.
+func (h *WebhookHandler) Start(ctx context.Context) error {
+	ticker := time.NewTicker(h.options.FlushInterval)
+	defer ticker.Stop()
+	batch := make([]CloudEvent, 0, h.options.BatchSize)
+	for {
+		select {
+		case e := <-h.queue:
+			batch = append(batch, e)
+			if len(batch) >= h.options.BatchSize {
+				h.send(ctx, batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				h.send(ctx, batch)
+				batch = batch[:0]
+			}
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), webhookFinalFlushTimeout)
+			defer cancel()
+			for {
+				select {
+				case e := <-h.queue:
+					batch = append(batch, e)
+					if len(batch) < h.options.BatchSize {
+						continue
+					}
+				default:
+				}
+				if len(batch) == 0 {
+					return nil
+				}
+				h.send(flushCtx, batch)
+				batch = batch[:0]
+			}
+		}
+	}
+}
+
+// send posts a batch of events, retrying with an exponential backoff when
+// the webhook is unavailable.
+func (h *WebhookHandler) send(ctx context.Context, batch []CloudEvent) {
+	body, err := json.Marshal(batch)
+	if err != nil {
+		h.logger.Error(err, "Failed to encode events", "events", len(batch))
+		return
+	}
+	backoff := 100 * time.Millisecond
+	for attempt := 0; ; attempt++ {
+		retry, err := h.post(ctx, body)
+		if err == nil {
+			return
+		}
+		if !retry || attempt >= h.options.MaxRetries {
+			h.logger.Error(err, "Failed to send events", "events", len(batch), "attempts", attempt+1)
+			return
+		}
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+		case <-ctx.Done():
+			h.logger.Error(err, "Failed to send events", "events", len(batch), "attempts", attempt+1)
+			return
+		}
+	}
+}
+
+// post posts the body to the webhook, returning whether a failure is worth
+// retrying.
+func (h *WebhookHandler) post(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.options.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", cloudEventsBatchContentType)
+	resp, err := h.options.Client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	switch {
+	case resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("webhook responded with %s", resp.Status)
+	default:
+		return false, fmt.Errorf("webhook rejected the events with %s", resp.Status)
+	}
+}
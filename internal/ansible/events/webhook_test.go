@@ -0,0 +1,191 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+// webhookServer records the batches posted to it, failing the first
+// failures requests.
+type webhookServer struct {
+	mu       sync.Mutex
+	failures int
+	requests int
+	batches  [][]CloudEvent
+}
+
+func (s *webhookServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+	if s.failures > 0 {
+		s.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	if ct := r.Header.Get("Content-Type"); ct != cloudEventsBatchContentType {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		return
+	}
+	batch := []CloudEvent{}
+	if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.batches = append(s.batches, batch)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (s *webhookServer) received() (int, [][]CloudEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests, append([][]CloudEvent{}, s.batches...)
+}
+
+func newResource(gvk schema.GroupVersionKind) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	u.SetName("example")
+	u.SetNamespace("default")
+	return u
+}
+
+func TestWebhookHandler(t *testing.T) {
+	server := &webhookServer{failures: 1}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	memcached := schema.GroupVersionKind{Group: "cache.example.com", Version: "v1alpha1", Kind: "Memcached"}
+	other := schema.GroupVersionKind{Group: "cache.example.com", Version: "v1alpha1", Kind: "Redis"}
+	h, err := NewWebhookHandler(WebhookOptions{
+		URL:           ts.URL,
+		GVKs:          []schema.GroupVersionKind{memcached},
+		BatchSize:     2,
+		FlushInterval: time.Hour,
+		MaxRetries:    3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		_ = h.Start(ctx)
+	}()
+
+	h.Handle("1", newResource(memcached), eventapi.JobEvent{UUID: "a", Event: eventapi.EventRunnerOnOk})
+	h.Handle("1", newResource(memcached), eventapi.JobEvent{UUID: "b", Event: eventapi.EventRunnerOnFailed})
+	h.Handle("2", newResource(other), eventapi.JobEvent{UUID: "c", Event: eventapi.EventRunnerOnFailed})
+	h.Handle("1", newResource(memcached), eventapi.JobEvent{UUID: "d", Event: eventapi.EventPlaybookOnStats})
+	h.Handle("3", newResource(memcached), eventapi.JobEvent{UUID: "e", Event: eventapi.EventPlaybookOnStats})
+
+	// the first batch is full, and sent again after the webhook failed.
+	deadline := time.Now().Add(5 * time.Second)
+	for _, batches := server.received(); len(batches) == 0; _, batches = server.received() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for the first batch")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	// the last event is sent when the handler stops.
+	cancel()
+	<-stopped
+
+	requests, batches := server.received()
+	if requests != 3 {
+		t.Fatalf("Unexpected number of requests %d", requests)
+	}
+	ids := [][]string{}
+	for _, batch := range batches {
+		batchIDs := []string{}
+		for _, e := range batch {
+			batchIDs = append(batchIDs, e.ID)
+		}
+		ids = append(ids, batchIDs)
+	}
+	if len(ids) != 2 || len(ids[0]) != 2 || ids[0][0] != "b" || ids[0][1] != "d" ||
+		len(ids[1]) != 1 || ids[1][0] != "e" {
+		t.Fatalf("Unexpected batches %v", ids)
+	}
+
+	e := batches[0][0]
+	if e.SpecVersion != "1.0" || e.Type != "io.operatorframework.ansible.runner_on_failed" ||
+		e.Source != "ansible-operator" || e.Subject != "default/example" {
+		t.Fatalf("Unexpected CloudEvent %+v", e)
+	}
+	if e.Data.Job != "1" || e.Data.APIVersion != "cache.example.com/v1alpha1" || e.Data.Kind != "Memcached" ||
+		e.Data.Event.UUID != "b" {
+		t.Fatalf("Unexpected CloudEvent data %+v", e.Data)
+	}
+}
+
+func TestWebhookHandlerNoRetries(t *testing.T) {
+	server := &webhookServer{failures: 1}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	h, err := NewWebhookHandler(WebhookOptions{URL: ts.URL, BatchSize: 1, FlushInterval: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		_ = h.Start(ctx)
+	}()
+
+	memcached := schema.GroupVersionKind{Group: "cache.example.com", Version: "v1alpha1", Kind: "Memcached"}
+	h.Handle("1", newResource(memcached), eventapi.JobEvent{UUID: "a", Event: eventapi.EventRunnerOnFailed})
+	deadline := time.Now().Add(5 * time.Second)
+	for requests, _ := server.received(); requests == 0; requests, _ = server.received() {
+		if time.Now().After(deadline) {
+			t.Fatalf("Timed out waiting for the batch")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	<-stopped
+
+	// the batch is dropped once the webhook failed.
+	if requests, batches := server.received(); requests != 1 || len(batches) != 0 {
+		t.Fatalf("Unexpected %d requests with the batches %v", requests, batches)
+	}
+}
+
+func TestNewWebhookHandlerInvalid(t *testing.T) {
+	for _, options := range []WebhookOptions{
+		{URL: "unix:///tmp/socket"},
+		{URL: "http://example.com", BatchSize: -1},
+		{URL: "http://example.com", MaxRetries: -1},
+	} {
+		if _, err := NewWebhookHandler(options); err == nil {
+			t.Fatalf("Expected an error for %+v", options)
+		}
+	}
+}
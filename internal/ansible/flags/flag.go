@@ -24,6 +24,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/events"
 )
 
 // Flags - Options to be used by an ansible operator
@@ -45,8 +47,31 @@ type Flags struct {
 	RenewDeadline              time.Duration
 	GracefulShutdownTimeout    time.Duration
 	AnsibleArgs                string
+	AnsibleRecordDir           string
+	AnsibleReplayDir           string
+	AnsibleExecutor            string
 	AnsibleLogEvents           string
+	AnsibleLogFormat           string
+	EventHandlerBuffer         int
+	EventHandlerOverflow       string
+	EventWebhookURL            string
+	EventWebhookEvents         []string
+	EventWebhookGVKs           []string
+	EventWebhookBatchSize      int
+	EventWebhookFlushInterval  time.Duration
+	EventWebhookRetries        int
+	TracingEndpoint            string
+	TracingFile                string
 	ProxyPort                  int
+	ProxySocket                string
+	ProxyAuditLog              string
+	ProxyAuditLogBodies        bool
+	ProxyAuditLogMaxSize       int
+	ProxyAuditLogMaxBackups    int
+	ProxyReadYourWritesTimeout time.Duration
+	DependentWatchGracePeriod  time.Duration
+	RunsBindAddress            string
+	RunsTokenFile              string
 	EnableHTTP2                bool
 	SecureMetrics              bool
 	MetricsRequireRBAC         bool
@@ -97,6 +122,25 @@ func (f *Flags) AddTo(flagSet *pflag.FlagSet) {
 		"",
 		"Ansible args. Allows user to specify arbitrary arguments for ansible-based operators.",
 	)
+	flagSet.StringVar(&f.AnsibleExecutor,
+		"ansible-executor",
+		"ansible-runner",
+		"Program that runs playbooks and roles. Either ansible-runner, or ansible-playbook to run them"+
+			" without ansible-runner installed.",
+	)
+	flagSet.StringVar(&f.AnsibleRecordDir,
+		"ansible-record-dir",
+		"",
+		"Directory to record the events and stdout of every ansible-runner job to, as JSONL fixtures"+
+			" that can be replayed in tests. Jobs are not recorded if unset.",
+	)
+	flagSet.StringVar(&f.AnsibleReplayDir,
+		"ansible-replay-dir",
+		"",
+		"Directory of JSONL fixtures, recorded with --ansible-record-dir, to replay in place of running"+
+			" ansible. The jobs of a custom resource replay its fixtures in the order they were recorded,"+
+			" and the last one after that. Ansible is run if unset.",
+	)
 
 	// Controller flags.
 	flagSet.DurationVar(&f.ReconcilePeriod,
@@ -186,11 +230,126 @@ func (f *Flags) AddTo(flagSet *pflag.FlagSet) {
 		"Ansible log events. The log level for console logging."+
 			" This flag can be set to either Nothing, Tasks, or Everything.",
 	)
+	flagSet.StringVar(&f.AnsibleLogFormat,
+		"ansible-log-format",
+		string(events.TextLogFormat),
+		"Format of the ansible output logged. Either text, to print the stdout of ansible in blocks, or json,"+
+			" to log a structured record for each task result logged with the stdout as a field.",
+	)
+	flagSet.IntVar(&f.EventHandlerBuffer,
+		"event-handler-buffer",
+		events.DefaultBufferSize,
+		"Number of ansible events queued for each event handler, in the order they were received.",
+	)
+	flagSet.StringVar(&f.EventHandlerOverflow,
+		"event-handler-overflow",
+		string(events.OverflowBlock),
+		"What happens to an ansible event when the queue of an event handler is full. Either block to wait"+
+			" for room, which holds back the run, drop-oldest or drop-newest.",
+	)
+	flagSet.StringVar(&f.EventWebhookURL,
+		"event-webhook-url",
+		"",
+		"URL to forward ansible events to, in batches of CloudEvents. Events are not forwarded if unset.",
+	)
+	flagSet.StringSliceVar(&f.EventWebhookEvents,
+		"event-webhook-events",
+		events.DefaultWebhookEventTypes,
+		"Types of the ansible events forwarded to the event webhook.",
+	)
+	flagSet.StringSliceVar(&f.EventWebhookGVKs,
+		"event-webhook-gvks",
+		nil,
+		"Kinds, as Kind.version.group, whose runs have their events forwarded to the event webhook."+
+			" Every kind is forwarded if unset.",
+	)
+	flagSet.IntVar(&f.EventWebhookBatchSize,
+		"event-webhook-batch-size",
+		10,
+		"Number of events forwarded at most in a single request to the event webhook.",
+	)
+	flagSet.DurationVar(&f.EventWebhookFlushInterval,
+		"event-webhook-flush-interval",
+		5*time.Second,
+		"How long an event may wait for its batch to fill up before it is forwarded to the event webhook.",
+	)
+	flagSet.IntVar(&f.EventWebhookRetries,
+		"event-webhook-retries",
+		3,
+		"Number of times a batch of events is forwarded again after the event webhook failed."+
+			" Zero disables the retries.",
+	)
+	flagSet.StringVar(&f.TracingEndpoint,
+		"tracing-endpoint",
+		"",
+		"URL of an OTLP gRPC collector the traces of reconciles, ansible runs, tasks and proxied API calls are"+
+			" exported to, e.g. http://localhost:4317. TLS is used unless the scheme is http.",
+	)
+	flagSet.StringVar(&f.TracingFile,
+		"tracing-file",
+		"",
+		"Path of a file the traces of reconciles, ansible runs, tasks and proxied API calls are written to,"+
+			" as JSON objects.",
+	)
 	flagSet.IntVar(&f.ProxyPort,
 		"proxy-port",
 		8888,
 		"Ansible proxy server port. Defaults to 8888.",
 	)
+	flagSet.StringVar(&f.ProxySocket,
+		"proxy-socket",
+		"",
+		"Path of a unix socket the ansible proxy server serves on instead of --proxy-port. Only the user of the"+
+			" operator may connect to it, and the kubernetes.core modules of the runs are routed to it. Other"+
+			" clients, e.g. kubectl or the ansible.builtin.uri module, cannot reach the proxy through it.",
+	)
+	flagSet.StringVar(&f.ProxyAuditLog,
+		"proxy-audit-log",
+		"",
+		"Path of a file the API requests of the runs are recorded to, one JSON record per request with the"+
+			" custom resource and the job that made it, or - for stdout. Requests are not recorded if unset.",
+	)
+	flagSet.BoolVar(&f.ProxyAuditLogBodies,
+		"proxy-audit-log-bodies",
+		false,
+		"Record the bodies of the API requests of the runs in the audit log, with sensitive values redacted.",
+	)
+	flagSet.IntVar(&f.ProxyAuditLogMaxSize,
+		"proxy-audit-log-max-size",
+		100,
+		"Size in megabytes past which the audit log file is rotated. Never rotated if 0.",
+	)
+	flagSet.IntVar(&f.ProxyAuditLogMaxBackups,
+		"proxy-audit-log-max-backups",
+		5,
+		"Number of rotated audit log files kept.",
+	)
+	flagSet.DurationVar(&f.ProxyReadYourWritesTimeout,
+		"proxy-read-your-writes-timeout",
+		2*time.Second,
+		"How long a read of a run served from the cache of the proxy waits for the cache to observe the writes"+
+			" of the run to the objects read, before being served by the API server instead. Reads never wait if 0.",
+	)
+	flagSet.DurationVar(&f.DependentWatchGracePeriod,
+		"dependent-watch-grace-period",
+		0,
+		"How long the watch of a dependent resource is kept once no custom resource owns an object of the"+
+			" resource anymore, before it is removed with its informer. The informers of the resources read"+
+			" from the cache are likewise removed once not read for that long. The watches are never removed"+
+			" if 0, the default.",
+	)
+	flagSet.StringVar(&f.RunsBindAddress,
+		"runs-bind-address",
+		"localhost:5051",
+		"The address the read-only /runs endpoints introspecting the runs are served on. They are served"+
+			" apart from the local apiserver on localhost:5050, which the runs post their metrics and outputs to."+
+			" A non-loopback address requires --runs-token-file. The endpoints are not served if empty.",
+	)
+	flagSet.StringVar(&f.RunsTokenFile,
+		"runs-token-file",
+		"",
+		"Path of a file holding the bearer token required by the /runs endpoints. No token is required if unset.",
+	)
 	flagSet.BoolVar(&f.EnableHTTP2,
 		"enable-http2",
 		false,
@@ -0,0 +1,97 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package impersonation names the ServiceAccount the runs of a watch act as
+// when making API calls through the proxy, so that a run can only do what
+// the RBAC of that ServiceAccount allows, rather than all that the operator
+// may do.
+package impersonation
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// ServiceAccount - the ServiceAccount to impersonate, as a template rendered
+// for each custom resource. A nil ServiceAccount impersonates nobody.
+type ServiceAccount struct {
+	template *template.Template
+}
+
+// values holds the data the template is rendered with.
+type values struct {
+	Namespace   string
+	Name        string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// New - parses the template of the ServiceAccount to impersonate, e.g.
+// "{{ .Namespace }}/tenant-runner". It renders to "<namespace>/<name>", or
+// to "<name>" for a ServiceAccount in the namespace of the custom resource.
+// The ServiceAccount of a namespaced custom resource must be in its
+// namespace, so that its author cannot make the runs act as a ServiceAccount
+// of another namespace, e.g. through its labels. No ServiceAccount is
+// impersonated if text is empty.
+func New(text string) (*ServiceAccount, error) {
+	if text == "" {
+		return nil, nil
+	}
+	t, err := template.New("serviceAccount").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid service account template %q: %w", text, err)
+	}
+	return &ServiceAccount{template: t}, nil
+}
+
+// User - the username of the ServiceAccount to impersonate for u, or "" if
+// none.
+func (s *ServiceAccount) User(u *unstructured.Unstructured) (string, error) {
+	if s == nil {
+		return "", nil
+	}
+	var b strings.Builder
+	err := s.template.Execute(&b, values{
+		Namespace:   u.GetNamespace(),
+		Name:        u.GetName(),
+		Labels:      u.GetLabels(),
+		Annotations: u.GetAnnotations(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to render the service account to impersonate: %w", err)
+	}
+
+	rendered := strings.TrimSpace(b.String())
+	namespace, name, ok := strings.Cut(rendered, "/")
+	if !ok {
+		namespace, name = u.GetNamespace(), rendered
+	}
+	if u.GetNamespace() != "" && namespace != u.GetNamespace() {
+		return "", fmt.Errorf("the service account %q to impersonate is not in the namespace %q of the resource",
+			rendered, u.GetNamespace())
+	}
+	if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+		return "", fmt.Errorf("invalid namespace %q of the service account %q to impersonate: %s",
+			namespace, rendered, strings.Join(errs, ", "))
+	}
+	if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+		return "", fmt.Errorf("invalid name %q of the service account %q to impersonate: %s",
+			name, rendered, strings.Join(errs, ", "))
+	}
+	return fmt.Sprintf("system:serviceaccount:%s:%s", namespace, name), nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impersonation
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestUser(t *testing.T) {
+	u := &unstructured.Unstructured{}
+	u.SetNamespace("tenant-a")
+	u.SetName("example")
+	u.SetLabels(map[string]string{"runner": "custom-runner"})
+
+	testCases := []struct {
+		name        string
+		template    string
+		expected    string
+		shouldError bool
+	}{
+		{
+			name:     "no impersonation",
+			template: "",
+			expected: "",
+		},
+		{
+			name:        "service account in another namespace",
+			template:    "ops/tenant-runner",
+			shouldError: true,
+		},
+		{
+			name:        "service account in another namespace templated from the labels",
+			template:    `{{ index .Labels "runner" }}/tenant-runner`,
+			shouldError: true,
+		},
+		{
+			name:     "service account in the namespace of the custom resource",
+			template: "tenant-runner",
+			expected: "system:serviceaccount:tenant-a:tenant-runner",
+		},
+		{
+			name:     "templated namespace",
+			template: "{{ .Namespace }}/tenant-runner",
+			expected: "system:serviceaccount:tenant-a:tenant-runner",
+		},
+		{
+			name:     "templated from the labels",
+			template: `{{ index .Labels "runner" }}`,
+			expected: "system:serviceaccount:tenant-a:custom-runner",
+		},
+		{
+			name:        "invalid name",
+			template:    "{{ .Namespace }}/Tenant_Runner",
+			shouldError: true,
+		},
+		{
+			name:        "missing label",
+			template:    `{{ .Labels.missing }}`,
+			shouldError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := New(tc.template)
+			if err != nil {
+				t.Fatal(err)
+			}
+			user, err := s.User(u)
+			if tc.shouldError {
+				if err == nil {
+					t.Fatalf("Expected an error, got user %q", user)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if user != tc.expected {
+				t.Fatalf("Unexpected user %q, expected %q", user, tc.expected)
+			}
+		})
+	}
+}
+
+func TestUserClusterScoped(t *testing.T) {
+	u := &unstructured.Unstructured{}
+	u.SetName("example")
+	s, err := New("tenant-runner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.User(u); err == nil {
+		t.Fatal("Expected an error without the namespace of the service account")
+	}
+
+	// a cluster scoped resource has no namespace to keep the service
+	// account to.
+	s, err = New("ops/tenant-runner")
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, err := s.User(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "system:serviceaccount:ops:tenant-runner"; user != expected {
+		t.Fatalf("Unexpected user %q, expected %q", user, expected)
+	}
+}
+
+func TestNewInvalid(t *testing.T) {
+	if _, err := New("{{ .Namespace }/tenant-runner"); err == nil {
+		t.Fatal("Expected an error for an invalid template")
+	}
+}
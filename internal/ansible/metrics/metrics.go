@@ -17,8 +17,13 @@ package metrics
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
@@ -62,12 +67,169 @@ var (
 			"GVK",
 		})
 
-	userMetrics = map[string]prometheus.Collector{}
+	eventsProcessed = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Subsystem: subsystem,
+			Name:      "events_processed_total",
+			Help:      "Number of ansible events passed to their run.",
+		})
+
+	eventsDropped = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: subsystem,
+			Name:      "events_dropped_total",
+			Help:      "Number of ansible events dropped, by reason.",
+		},
+		[]string{
+			"reason",
+		})
+
+	eventsQueued = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Subsystem: subsystem,
+			Name:      "events_queued",
+			Help:      "Number of ansible events waiting for their run to take them.",
+		})
+
+	taskDurations = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: subsystem,
+			Name:      "task_duration_seconds",
+			Help:      "How long in seconds an ansible task takes, by role and task name.",
+			Buckets:   []float64{0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300},
+		},
+		[]string{
+			"GVK",
+			"role",
+			"task",
+		})
+
+	taskResults = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: subsystem,
+			Name:      "task_results_total",
+			Help:      "Number of ansible tasks run, by result: ok, changed, failed, ignored, skipped or unreachable.",
+		},
+		[]string{
+			"GVK",
+			"result",
+		})
+
+	runsInFlight = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: subsystem,
+			Name:      "runs_in_flight",
+			Help:      "Number of ansible runs in progress.",
+		},
+		[]string{
+			"GVK",
+		})
+
+	finalizerRuns = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: subsystem,
+			Name:      "finalizer_runs_total",
+			Help:      "Number of ansible runs of a finalizer.",
+		},
+		[]string{
+			"GVK",
+		})
+
+	reconcileStartDelays = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: subsystem,
+			Name:      "reconcile_start_delay_seconds",
+			Help:      "How long in seconds from an event on a resource to the start of its reconcile.",
+		},
+		[]string{
+			"GVK",
+		})
+
+	proxyRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: subsystem,
+			Name:      "proxy_requests_total",
+			Help:      "Number of API requests made through the proxy, by cache outcome: hit, miss, skip or virtual.",
+		},
+		[]string{
+			"verb",
+			"resource",
+			"GVK",
+			"cache",
+		})
+
+	proxyRequestDurations = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Subsystem: subsystem,
+			Name:      "proxy_request_duration_seconds",
+			Help:      "How long in seconds the proxy takes to serve an API request, by cache outcome.",
+		},
+		[]string{
+			"verb",
+			"resource",
+			"GVK",
+			"cache",
+		})
+
+	ownerReferenceInjections = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: subsystem,
+			Name:      "owner_reference_injections_total",
+			Help:      "Number of resources created through the proxy given an owner reference to their custom resource.",
+		},
+		[]string{
+			"GVK",
+		})
+
+	ownerAnnotationFallbacks = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: subsystem,
+			Name:      "owner_annotation_fallbacks_total",
+			Help: "Number of resources created through the proxy given owner annotations, as their custom" +
+				" resource cannot own them with an owner reference.",
+		},
+		[]string{
+			"GVK",
+		})
+
+	dependentWatches = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Subsystem: subsystem,
+			Name:      "dependent_watches",
+			Help:      "Number of kinds of dependent resources watched, by how their owner is found: owner or annotation.",
+		},
+		[]string{
+			"GVK",
+			"type",
+		})
+
+	// taskLabels holds the role and task names seen for each GVK, to keep
+	// the cardinality of the task metrics bounded.
+	taskLabels      = map[string]map[[2]string]bool{}
+	taskLabelsMutex sync.Mutex
 )
 
+// maxTasksPerGVK - the number of distinct role and task names the duration
+// of tasks is observed for, for each GVK. The tasks seen after that are
+// observed with the role and task name "other".
+const maxTasksPerGVK = 200
+
 func init() {
 	metrics.Registry.MustRegister(reconcileResults)
 	metrics.Registry.MustRegister(reconciles)
+	metrics.Registry.MustRegister(eventsProcessed)
+	metrics.Registry.MustRegister(eventsDropped)
+	metrics.Registry.MustRegister(eventsQueued)
+	metrics.Registry.MustRegister(taskDurations)
+	metrics.Registry.MustRegister(taskResults)
+	metrics.Registry.MustRegister(runsInFlight)
+	metrics.Registry.MustRegister(finalizerRuns)
+	metrics.Registry.MustRegister(reconcileStartDelays)
+	metrics.Registry.MustRegister(proxyRequests)
+	metrics.Registry.MustRegister(proxyRequestDurations)
+	metrics.Registry.MustRegister(ownerReferenceInjections)
+	metrics.Registry.MustRegister(ownerAnnotationFallbacks)
+	metrics.Registry.MustRegister(dependentWatches)
 }
 
 // We will never want to panic our app because of metric saving.
@@ -85,6 +247,8 @@ func RegisterBuildInfo(r prometheus.Registerer) {
 	r.MustRegister(buildInfo)
 }
 
+// UserMetric - a change to a metric defined by the operator's playbooks and
+// roles, posted to the apiserver.
 type UserMetric struct {
 	Name      string               `json:"name" yaml:"name"`
 	Help      string               `json:"description" yaml:"description"`
@@ -92,6 +256,20 @@ type UserMetric struct {
 	Gauge     *UserMetricGauge     `json:"gauge,omitempty" yaml:"gauge,omitempty"`
 	Histogram *UserMetricHistogram `json:"histogram,omitempty" yaml:"histogram,omitempty"`
 	Summary   *UserMetricSummary   `json:"summary,omitempty" yaml:"summary,omitempty"`
+	// Labels are the names and values of the labels of the series changed.
+	// The label names of a metric are set when it is first registered.
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+	// CR scopes the series to a custom resource: the series gets its group,
+	// kind, namespace and name as labels, and is deleted when it is removed.
+	CR *UserMetricCR `json:"cr,omitempty" yaml:"cr,omitempty"`
+}
+
+// UserMetricCR - the custom resource a series of a user metric belongs to.
+type UserMetricCR struct {
+	Group     string `json:"group" yaml:"group"`
+	Kind      string `json:"kind" yaml:"kind"`
+	Namespace string `json:"namespace" yaml:"namespace"`
+	Name      string `json:"name" yaml:"name"`
 }
 
 type UserMetricCounter struct {
@@ -110,12 +288,58 @@ type UserMetricGauge struct {
 
 type UserMetricHistogram struct {
 	Observe float64 `json:"observe,omitempty" yaml:"observe,omitempty"`
+	// Buckets are the upper bounds of the buckets of the histogram, in
+	// increasing order. Defaults to prometheus.DefBuckets.
+	Buckets []float64 `json:"buckets,omitempty" yaml:"buckets,omitempty"`
 }
 
 type UserMetricSummary struct {
 	Observe float64 `json:"observe,omitempty" yaml:"observe,omitempty"`
 }
 
+const (
+	counterMetric   = "counter"
+	gaugeMetric     = "gauge"
+	histogramMetric = "histogram"
+	summaryMetric   = "summary"
+
+	// crGroupLabel, crKindLabel, crNamespaceLabel and crNameLabel are the
+	// labels added to the series scoped to a custom resource.
+	crGroupLabel     = "group"
+	crKindLabel      = "kind"
+	crNamespaceLabel = "namespace"
+	crNameLabel      = "name"
+)
+
+// userMetric - a registered user metric, and what its series are changed with.
+type userMetric struct {
+	kind       string
+	labelNames []string
+	crScoped   bool
+	buckets    []float64
+	collector  prometheus.Collector
+	vec        *prometheus.MetricVec
+}
+
+var (
+	userMetrics      = map[string]*userMetric{}
+	userMetricsMutex sync.Mutex
+)
+
+func metricKind(metricSpec UserMetric) string {
+	switch {
+	case metricSpec.Counter != nil:
+		return counterMetric
+	case metricSpec.Gauge != nil:
+		return gaugeMetric
+	case metricSpec.Histogram != nil:
+		return histogramMetric
+	case metricSpec.Summary != nil:
+		return summaryMetric
+	}
+	return ""
+}
+
 func validateMetricSpec(metricSpec UserMetric) error {
 	var metricConfigs int
 	if metricSpec.Counter != nil {
@@ -135,13 +359,65 @@ func validateMetricSpec(metricSpec UserMetric) error {
 	} else if metricConfigs == 0 {
 		return errors.New("a request should contain at least one metric")
 	}
+	if metricSpec.Histogram != nil {
+		for i := 1; i < len(metricSpec.Histogram.Buckets); i++ {
+			if metricSpec.Histogram.Buckets[i] <= metricSpec.Histogram.Buckets[i-1] {
+				return fmt.Errorf("buckets of histogram %s must be in increasing order", metricSpec.Name)
+			}
+		}
+	}
+	return validateSeriesSpec(metricSpec)
+}
+
+// validateSeriesSpec validates the metric name, labels and custom resource
+// identifying a series.
+func validateSeriesSpec(metricSpec UserMetric) error {
+	if metricSpec.Name == "" {
+		return errors.New("a request should contain the name of the metric")
+	}
+	if metricSpec.CR != nil {
+		if metricSpec.CR.Kind == "" || metricSpec.CR.Name == "" {
+			return fmt.Errorf("the custom resource of metric %s should have a kind and a name", metricSpec.Name)
+		}
+		for _, l := range []string{crGroupLabel, crKindLabel, crNamespaceLabel, crNameLabel} {
+			if _, ok := metricSpec.Labels[l]; ok {
+				return fmt.Errorf("label %q of metric %s is reserved for its custom resource", l, metricSpec.Name)
+			}
+		}
+	}
 	return nil
 }
 
-func handleCounter(metricSpec UserMetric, counter prometheus.Counter) error {
-	if metricSpec.Counter == nil {
-		return fmt.Errorf("cannot change metric type of %s, which is a counter", metricSpec.Name)
+// seriesLabels returns the label names of the series of metricSpec, sorted,
+// and their values.
+func seriesLabels(metricSpec UserMetric) ([]string, []string) {
+	names := make([]string, 0, len(metricSpec.Labels)+4)
+	for name := range metricSpec.Labels {
+		names = append(names, name)
+	}
+	if metricSpec.CR != nil {
+		names = append(names, crGroupLabel, crKindLabel, crNamespaceLabel, crNameLabel)
+	}
+	sort.Strings(names)
+	values := make([]string, 0, len(names))
+	for _, name := range names {
+		switch {
+		case metricSpec.CR != nil && name == crGroupLabel:
+			values = append(values, metricSpec.CR.Group)
+		case metricSpec.CR != nil && name == crKindLabel:
+			values = append(values, metricSpec.CR.Kind)
+		case metricSpec.CR != nil && name == crNamespaceLabel:
+			values = append(values, metricSpec.CR.Namespace)
+		case metricSpec.CR != nil && name == crNameLabel:
+			values = append(values, metricSpec.CR.Name)
+		default:
+			values = append(values, metricSpec.Labels[name])
+		}
 	}
+	return names, values
+}
+
+func handleCounter(metricSpec UserMetric, counter prometheus.Counter) error {
 	if metricSpec.Counter.Inc {
 		counter.Inc()
 	} else if metricSpec.Counter.Add != 0.0 {
@@ -154,9 +430,6 @@ func handleCounter(metricSpec UserMetric, counter prometheus.Counter) error {
 }
 
 func handleGauge(metricSpec UserMetric, gauge prometheus.Gauge) error {
-	if metricSpec.Gauge == nil {
-		return fmt.Errorf("cannot change metric type of %s, which is a gauge", metricSpec.Name)
-	}
 	if metricSpec.Gauge.Inc {
 		gauge.Inc()
 	} else if metricSpec.Gauge.Dec {
@@ -173,10 +446,7 @@ func handleGauge(metricSpec UserMetric, gauge prometheus.Gauge) error {
 	return nil
 }
 
-func handleSummaryOrHistogram(metricSpec UserMetric, summary prometheus.Summary) error {
-	if metricSpec.Histogram == nil && metricSpec.Summary == nil {
-		return fmt.Errorf("cannot change metric type of %s, which is a histogram or summary", metricSpec.Name)
-	}
+func handleSummaryOrHistogram(metricSpec UserMetric, summary prometheus.Observer) error {
 	if metricSpec.Histogram != nil {
 		summary.Observe(metricSpec.Histogram.Observe)
 	} else if metricSpec.Summary != nil {
@@ -185,65 +455,145 @@ func handleSummaryOrHistogram(metricSpec UserMetric, summary prometheus.Summary)
 	return nil
 }
 
-func ensureMetric(r prometheus.Registerer, metricSpec UserMetric) {
-	if _, ok := userMetrics[metricSpec.Name]; !ok {
-		// This is the first time we've seen this metric
-		logf.Log.WithName("metrics").Info("Registering", "metric", metricSpec.Name)
-		if metricSpec.Counter != nil {
-			userMetrics[metricSpec.Name] = prometheus.NewCounter(prometheus.CounterOpts{
-				Name: metricSpec.Name,
-				Help: metricSpec.Help,
-			})
+// ensureMetric returns the metric registered under the name of metricSpec,
+// registering it the first time it is seen. It fails if the metric was
+// registered with another type, other labels or other buckets.
+func ensureMetric(r prometheus.Registerer, metricSpec UserMetric) (*userMetric, error) {
+	kind := metricKind(metricSpec)
+	labelNames, _ := seriesLabels(metricSpec)
+	var buckets []float64
+	if metricSpec.Histogram != nil {
+		buckets = metricSpec.Histogram.Buckets
+	}
+
+	if m, ok := userMetrics[metricSpec.Name]; ok {
+		if m.kind != kind {
+			return nil, fmt.Errorf("cannot change metric type of %s, which is a %s", metricSpec.Name, m.kind)
 		}
-		if metricSpec.Gauge != nil {
-			userMetrics[metricSpec.Name] = prometheus.NewGauge(prometheus.GaugeOpts{
-				Name: metricSpec.Name,
-				Help: metricSpec.Help,
-			})
+		if m.crScoped != (metricSpec.CR != nil) {
+			if m.crScoped {
+				return nil, fmt.Errorf("metric %s is scoped to custom resources", metricSpec.Name)
+			}
+			return nil, fmt.Errorf("metric %s is not scoped to custom resources", metricSpec.Name)
 		}
-		if metricSpec.Histogram != nil {
-			userMetrics[metricSpec.Name] = prometheus.NewHistogram(prometheus.HistogramOpts{
-				Name: metricSpec.Name,
-				Help: metricSpec.Help,
-			})
+		if !slices.Equal(m.labelNames, labelNames) {
+			return nil, fmt.Errorf("metric %s has the labels %v, not %v", metricSpec.Name, m.labelNames, labelNames)
 		}
-		if metricSpec.Summary != nil {
-			userMetrics[metricSpec.Name] = prometheus.NewSummary(prometheus.SummaryOpts{
-				Name: metricSpec.Name,
-				Help: metricSpec.Help,
-			})
+		if len(buckets) > 0 && !slices.Equal(m.buckets, buckets) {
+			return nil, fmt.Errorf("histogram %s has the buckets %v, not %v", metricSpec.Name, m.buckets, buckets)
 		}
-		if err := r.Register(userMetrics[metricSpec.Name]); err != nil {
-			logf.Log.WithName("metrics").Info("Unable to register %s metric with prometheus.", metricSpec.Name)
+		return m, nil
+	}
+
+	// This is the first time we've seen this metric
+	logf.Log.WithName("metrics").Info("Registering", "metric", metricSpec.Name, "type", kind,
+		"labels", labelNames)
+	m := &userMetric{kind: kind, labelNames: labelNames, crScoped: metricSpec.CR != nil}
+	switch kind {
+	case counterMetric:
+		v := prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: metricSpec.Name,
+			Help: metricSpec.Help,
+		}, labelNames)
+		m.collector, m.vec = v, v.MetricVec
+	case gaugeMetric:
+		v := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: metricSpec.Name,
+			Help: metricSpec.Help,
+		}, labelNames)
+		m.collector, m.vec = v, v.MetricVec
+	case histogramMetric:
+		m.buckets = buckets
+		if len(m.buckets) == 0 {
+			m.buckets = prometheus.DefBuckets
 		}
+		v := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    metricSpec.Name,
+			Help:    metricSpec.Help,
+			Buckets: m.buckets,
+		}, labelNames)
+		m.collector, m.vec = v, v.MetricVec
+	case summaryMetric:
+		v := prometheus.NewSummaryVec(prometheus.SummaryOpts{
+			Name: metricSpec.Name,
+			Help: metricSpec.Help,
+		}, labelNames)
+		m.collector, m.vec = v, v.MetricVec
 	}
+	if err := r.Register(m.collector); err != nil {
+		return nil, fmt.Errorf("unable to register metric %s: %w", metricSpec.Name, err)
+	}
+	userMetrics[metricSpec.Name] = m
+	return m, nil
 }
 
+// HandleUserMetric - changes the series of a user metric, registering the
+// metric the first time it is seen.
 func HandleUserMetric(r prometheus.Registerer, metricSpec UserMetric) error {
 	if err := validateMetricSpec(metricSpec); err != nil {
 		return err
 	}
-	ensureMetric(r, metricSpec)
-	collector := userMetrics[metricSpec.Name]
-	switch v := collector.(type) {
-	// Gauge must be first, because a Counter is a Gauge, but a Gauge is not a Counter.
-	case prometheus.Gauge:
-		if err := handleGauge(metricSpec, v); err != nil {
-			return err
-		}
-	case prometheus.Counter:
-		if err := handleCounter(metricSpec, v); err != nil {
-			return err
-		}
-	// Histogram and Summary interfaces are identical, so we accept either case.
-	case prometheus.Histogram:
-		if err := handleSummaryOrHistogram(metricSpec, v); err != nil {
-			return err
-		}
+	userMetricsMutex.Lock()
+	defer userMetricsMutex.Unlock()
+	m, err := ensureMetric(r, metricSpec)
+	if err != nil {
+		return err
+	}
+	_, labelValues := seriesLabels(metricSpec)
+	series, err := m.vec.GetMetricWithLabelValues(labelValues...)
+	if err != nil {
+		return fmt.Errorf("invalid labels for metric %s: %w", metricSpec.Name, err)
+	}
+	switch m.kind {
+	case counterMetric:
+		return handleCounter(metricSpec, series.(prometheus.Counter))
+	case gaugeMetric:
+		return handleGauge(metricSpec, series.(prometheus.Gauge))
+	default:
+		return handleSummaryOrHistogram(metricSpec, series.(prometheus.Observer))
+	}
+}
+
+// DeleteUserMetric - deletes the series of a user metric with the labels and
+// custom resource of metricSpec. A metric without labels is unregistered.
+func DeleteUserMetric(r prometheus.Registerer, metricSpec UserMetric) error {
+	if err := validateSeriesSpec(metricSpec); err != nil {
+		return err
+	}
+	userMetricsMutex.Lock()
+	defer userMetricsMutex.Unlock()
+	m, ok := userMetrics[metricSpec.Name]
+	if !ok {
+		return fmt.Errorf("metric %s is not registered", metricSpec.Name)
+	}
+	if len(metricSpec.Labels) == 0 && metricSpec.CR == nil {
+		r.Unregister(m.collector)
+		delete(userMetrics, metricSpec.Name)
+		return nil
+	}
+	labelNames, labelValues := seriesLabels(metricSpec)
+	if !slices.Equal(m.labelNames, labelNames) {
+		return fmt.Errorf("metric %s has the labels %v, not %v", metricSpec.Name, m.labelNames, labelNames)
+	}
+	if !m.vec.DeleteLabelValues(labelValues...) {
+		return fmt.Errorf("metric %s has no series with the labels %v", metricSpec.Name, metricSpec.Labels)
 	}
 	return nil
 }
 
+// DeleteCRUserMetrics - deletes the series of the user metrics scoped to the
+// custom resource of the group and kind with the namespace and name.
+func DeleteCRUserMetrics(gk schema.GroupKind, namespace, name string) {
+	userMetricsMutex.Lock()
+	defer userMetricsMutex.Unlock()
+	for _, m := range userMetrics {
+		if m.crScoped {
+			m.vec.DeletePartialMatch(prometheus.Labels{crGroupLabel: gk.Group, crKindLabel: gk.Kind,
+				crNamespaceLabel: namespace, crNameLabel: name})
+		}
+	}
+}
+
 func ReconcileSucceeded(gvk string) {
 	defer recoverMetricPanic()
 	reconcileResults.WithLabelValues(gvk, "succeeded").Inc()
@@ -261,3 +611,128 @@ func ReconcileTimer(gvk string) *prometheus.Timer {
 		reconciles.WithLabelValues(gvk).Observe(duration)
 	}))
 }
+
+// EventProcessed - counts an event handed to the run it was posted for.
+func EventProcessed() {
+	defer recoverMetricPanic()
+	eventsProcessed.Inc()
+}
+
+// EventDropped - counts an event dropped for the given reason: "unknown_run"
+// if no run accepts events for its ident, "stopped" if its run has finished,
+// or "canceled" if ansible gave up on posting it.
+func EventDropped(reason string) {
+	defer recoverMetricPanic()
+	eventsDropped.WithLabelValues(reason).Inc()
+}
+
+// EventQueued - counts an event waiting for its run to take it, until
+// EventDequeued is called for it.
+func EventQueued() {
+	defer recoverMetricPanic()
+	eventsQueued.Inc()
+}
+
+// EventDequeued - stops counting an event counted by EventQueued, once it
+// was taken by its run or dropped.
+func EventDequeued() {
+	defer recoverMetricPanic()
+	eventsQueued.Dec()
+}
+
+// TaskFinished - counts a task with the given result, and observes its
+// duration if known.
+func TaskFinished(gvk, role, task, result string, duration time.Duration, hasDuration bool) {
+	defer recoverMetricPanic()
+	taskResults.WithLabelValues(gvk, result).Inc()
+	if hasDuration {
+		role, task = taskLabelValues(gvk, role, task)
+		taskDurations.WithLabelValues(gvk, role, task).Observe(duration.Seconds())
+	}
+}
+
+// taskLabelValues returns the role and task name to observe a task with,
+// which are "other" once maxTasksPerGVK tasks were seen for the GVK.
+func taskLabelValues(gvk, role, task string) (string, string) {
+	taskLabelsMutex.Lock()
+	defer taskLabelsMutex.Unlock()
+	seen, ok := taskLabels[gvk]
+	if !ok {
+		seen = map[[2]string]bool{}
+		taskLabels[gvk] = seen
+	}
+	key := [2]string{role, task}
+	if !seen[key] {
+		if len(seen) >= maxTasksPerGVK {
+			return "other", "other"
+		}
+		seen[key] = true
+	}
+	return role, task
+}
+
+// RunStarted - counts a run in progress, until the returned function is
+// called.
+func RunStarted(gvk string, finalizer bool) (done func()) {
+	done = func() {}
+	defer recoverMetricPanic()
+	if finalizer {
+		finalizerRuns.WithLabelValues(gvk).Inc()
+	}
+	runsInFlight.WithLabelValues(gvk).Inc()
+	return func() {
+		defer recoverMetricPanic()
+		runsInFlight.WithLabelValues(gvk).Dec()
+	}
+}
+
+// ReconcileStartDelay - observes how long a reconcile of a resource of gvk
+// started after the first event on the resource it reconciles.
+func ReconcileStartDelay(gvk string, delay time.Duration) {
+	defer recoverMetricPanic()
+	reconcileStartDelays.WithLabelValues(gvk).Observe(delay.Seconds())
+}
+
+// The cache outcomes of the API requests made through the proxy.
+const (
+	// CacheHit - the request was served from the cache of the proxy.
+	CacheHit = "hit"
+	// CacheMiss - the request was looked up in the cache, but served by the
+	// API server.
+	CacheMiss = "miss"
+	// CacheSkip - the request cannot be served from the cache, or the
+	// cache is disabled.
+	CacheSkip = "skip"
+	// CacheVirtual - the request is for a virtual resource, which is never
+	// cached.
+	CacheVirtual = "virtual"
+)
+
+// ProxyRequest - counts an API request made through the proxy for a run of
+// a custom resource of gvk, and observes how long it took.
+func ProxyRequest(verb, resource, gvk, cache string, duration time.Duration) {
+	defer recoverMetricPanic()
+	proxyRequests.WithLabelValues(verb, resource, gvk, cache).Inc()
+	proxyRequestDurations.WithLabelValues(verb, resource, gvk, cache).Observe(duration.Seconds())
+}
+
+// OwnerReferenceInjected - counts a resource given an owner reference to a
+// custom resource of gvk.
+func OwnerReferenceInjected(gvk string) {
+	defer recoverMetricPanic()
+	ownerReferenceInjections.WithLabelValues(gvk).Inc()
+}
+
+// OwnerAnnotationsInjected - counts a resource given owner annotations to a
+// custom resource of gvk.
+func OwnerAnnotationsInjected(gvk string) {
+	defer recoverMetricPanic()
+	ownerAnnotationFallbacks.WithLabelValues(gvk).Inc()
+}
+
+// DependentWatches - sets the number of kinds of dependent resources watched
+// for the custom resources of gvk, by watch type: owner or annotation.
+func DependentWatches(gvk, watchType string, n int) {
+	defer recoverMetricPanic()
+	dependentWatches.WithLabelValues(gvk, watchType).Set(float64(n))
+}
@@ -0,0 +1,198 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestTaskFinished(t *testing.T) {
+	gvk := "cache.example.com/v1alpha1, Kind=TaskFinished"
+	TaskFinished(gvk, "memcached", "create deployment", "changed", 2*time.Second, true)
+	TaskFinished(gvk, "memcached", "create deployment", "changed", time.Second, true)
+	TaskFinished(gvk, "memcached", "probe", "skipped", 0, false)
+
+	if n := testutil.ToFloat64(taskResults.WithLabelValues(gvk, "changed")); n != 2 {
+		t.Fatalf("Unexpected number of changed tasks %v", n)
+	}
+	if n := testutil.ToFloat64(taskResults.WithLabelValues(gvk, "skipped")); n != 1 {
+		t.Fatalf("Unexpected number of skipped tasks %v", n)
+	}
+	if n := testutil.CollectAndCount(taskDurations.MustCurryWith(map[string]string{"GVK": gvk})); n != 1 {
+		t.Fatalf("Unexpected number of task duration series %d", n)
+	}
+}
+
+func TestTaskLabelValuesCardinality(t *testing.T) {
+	gvk := "cache.example.com/v1alpha1, Kind=Cardinality"
+	for i := 0; i < maxTasksPerGVK; i++ {
+		role, task := taskLabelValues(gvk, "role", fmt.Sprintf("task %d", i))
+		if role != "role" || task != fmt.Sprintf("task %d", i) {
+			t.Fatalf("Unexpected labels %q, %q for task %d", role, task, i)
+		}
+	}
+	if role, task := taskLabelValues(gvk, "role", "one too many"); role != "other" || task != "other" {
+		t.Fatalf("Unexpected labels %q, %q past the limit", role, task)
+	}
+	// tasks already seen keep their labels.
+	if role, task := taskLabelValues(gvk, "role", "task 0"); role != "role" || task != "task 0" {
+		t.Fatalf("Unexpected labels %q, %q for a task already seen", role, task)
+	}
+	// the limit applies to each GVK.
+	if role, task := taskLabelValues(gvk+"2", "role", "one too many"); role != "role" || task != "one too many" {
+		t.Fatalf("Unexpected labels %q, %q for another GVK", role, task)
+	}
+}
+
+func TestHandleUserMetricLabels(t *testing.T) {
+	r := prometheus.NewRegistry()
+	for _, spec := range []UserMetric{
+		{Name: "test_labels_total", Help: "Test", Counter: &UserMetricCounter{Inc: true},
+			Labels: map[string]string{"color": "blue", "size": "small"}},
+		{Name: "test_labels_total", Counter: &UserMetricCounter{Add: 2},
+			Labels: map[string]string{"color": "blue", "size": "small"}},
+		{Name: "test_labels_total", Counter: &UserMetricCounter{Inc: true},
+			Labels: map[string]string{"color": "red", "size": "small"}},
+	} {
+		if err := HandleUserMetric(r, spec); err != nil {
+			t.Fatalf("Unexpected error for %+v: %v", spec, err)
+		}
+	}
+	expected := `# HELP test_labels_total Test
+# TYPE test_labels_total counter
+test_labels_total{color="blue",size="small"} 3
+test_labels_total{color="red",size="small"} 1
+`
+	if err := testutil.GatherAndCompare(r, strings.NewReader(expected), "test_labels_total"); err != nil {
+		t.Fatal(err)
+	}
+
+	err := DeleteUserMetric(r, UserMetric{Name: "test_labels_total",
+		Labels: map[string]string{"color": "blue", "size": "small"}})
+	if err != nil {
+		t.Fatalf("Unexpected error deleting a series: %v", err)
+	}
+	if n := testutil.CollectAndCount(userMetrics["test_labels_total"].collector); n != 1 {
+		t.Fatalf("Unexpected number of series %d after deleting one", n)
+	}
+	if err := DeleteUserMetric(r, UserMetric{Name: "test_labels_total"}); err != nil {
+		t.Fatalf("Unexpected error unregistering the metric: %v", err)
+	}
+	if err := testutil.GatherAndCompare(r, strings.NewReader(""), "test_labels_total"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHandleUserMetricConflicts(t *testing.T) {
+	r := prometheus.NewRegistry()
+	err := HandleUserMetric(r, UserMetric{Name: "test_conflicts", Gauge: &UserMetricGauge{Set: 1},
+		Labels: map[string]string{"color": "blue"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = HandleUserMetric(r, UserMetric{Name: "test_histogram", Histogram: &UserMetricHistogram{
+		Observe: 1, Buckets: []float64{1, 5}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, spec := range []UserMetric{
+		{Name: "test_conflicts", Counter: &UserMetricCounter{Inc: true},
+			Labels: map[string]string{"color": "blue"}},
+		{Name: "test_conflicts", Gauge: &UserMetricGauge{Inc: true},
+			Labels: map[string]string{"size": "small"}},
+		{Name: "test_conflicts", Gauge: &UserMetricGauge{Inc: true}},
+		{Name: "test_conflicts", Gauge: &UserMetricGauge{Inc: true},
+			Labels: map[string]string{"color": "blue"}, CR: &UserMetricCR{Kind: "Example", Namespace: "default",
+				Name: "example"}},
+		{Name: "test_histogram", Histogram: &UserMetricHistogram{Observe: 1, Buckets: []float64{1, 10}}},
+		{Name: "test_bad_buckets", Histogram: &UserMetricHistogram{Observe: 1, Buckets: []float64{5, 1}}},
+		{Name: "test_reserved", Gauge: &UserMetricGauge{Inc: true},
+			Labels: map[string]string{"name": "x"}, CR: &UserMetricCR{Kind: "Example", Namespace: "default",
+				Name: "example"}},
+		{Name: "test_no_kind", Gauge: &UserMetricGauge{Inc: true},
+			CR: &UserMetricCR{Namespace: "default", Name: "example"}},
+	} {
+		if err := HandleUserMetric(r, spec); err == nil {
+			t.Fatalf("Expected an error for %+v", spec)
+		}
+	}
+	// the buckets may be omitted once registered.
+	err = HandleUserMetric(r, UserMetric{Name: "test_histogram", Histogram: &UserMetricHistogram{Observe: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDeleteCRUserMetrics(t *testing.T) {
+	r := prometheus.NewRegistry()
+	for _, cr := range []UserMetricCR{
+		{Group: "cache.example.com", Kind: "Memcached", Namespace: "default", Name: "example"},
+		{Group: "cache.example.com", Kind: "Memcached", Namespace: "default", Name: "other"},
+		{Group: "cache.example.com", Kind: "Redis", Namespace: "default", Name: "example"},
+	} {
+		err := HandleUserMetric(r, UserMetric{Name: "test_cr_scoped", Help: "Test", Gauge: &UserMetricGauge{Set: 1},
+			Labels: map[string]string{"phase": "ready"}, CR: &cr})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	DeleteCRUserMetrics(schema.GroupKind{Group: "cache.example.com", Kind: "Memcached"}, "default", "example")
+	expected := `# HELP test_cr_scoped Test
+# TYPE test_cr_scoped gauge
+test_cr_scoped{group="cache.example.com",kind="Memcached",name="other",namespace="default",phase="ready"} 1
+test_cr_scoped{group="cache.example.com",kind="Redis",name="example",namespace="default",phase="ready"} 1
+`
+	if err := testutil.GatherAndCompare(r, strings.NewReader(expected), "test_cr_scoped"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProxyRequest(t *testing.T) {
+	gvk := "cache.example.com/v1alpha1, Kind=ProxyRequest"
+	ProxyRequest("get", "configmaps", gvk, CacheHit, time.Millisecond)
+	ProxyRequest("get", "configmaps", gvk, CacheHit, time.Millisecond)
+	ProxyRequest("create", "configmaps", gvk, CacheSkip, 10*time.Millisecond)
+
+	if n := testutil.ToFloat64(proxyRequests.WithLabelValues("get", "configmaps", gvk, CacheHit)); n != 2 {
+		t.Fatalf("Unexpected number of cache hits %v", n)
+	}
+	if n := testutil.ToFloat64(proxyRequests.WithLabelValues("create", "configmaps", gvk, CacheSkip)); n != 1 {
+		t.Fatalf("Unexpected number of creates %v", n)
+	}
+	if n := testutil.CollectAndCount(proxyRequestDurations.MustCurryWith(map[string]string{"GVK": gvk})); n != 2 {
+		t.Fatalf("Unexpected number of request duration series %d", n)
+	}
+}
+
+func TestDependentWatches(t *testing.T) {
+	gvk := "cache.example.com/v1alpha1, Kind=DependentWatches"
+	DependentWatches(gvk, "owner", 1)
+	DependentWatches(gvk, "owner", 2)
+	DependentWatches(gvk, "annotation", 1)
+	if n := testutil.ToFloat64(dependentWatches.WithLabelValues(gvk, "owner")); n != 2 {
+		t.Fatalf("Unexpected number of owner watches %v", n)
+	}
+	if n := testutil.ToFloat64(dependentWatches.WithLabelValues(gvk, "annotation")); n != 1 {
+		t.Fatalf("Unexpected number of annotation watches %v", n)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package outputs collects the outputs the runs post to the apiserver, to be
+// kept for the next run of their custom resource when the watch sets an
+// outputsStore. A run posts its outputs as a JSON object to the
+// /outputs/<job_ident> endpoint of the apiserver, authenticated with the token
+// issued to the run, e.g. with:
+//
+//	tasks:
+//	  - name: Set the outputs of the run
+//	    ansible.builtin.uri:
+//	      url: "http://localhost:5050/outputs/{{ ansible_operator_meta.job_ident }}"
+//	      method: POST
+//	      headers:
+//	        Authorization: "Bearer {{ lookup('env', 'ANSIBLE_OPERATOR_RUN_TOKEN') }}"
+//	      body_format: json
+//	      body:
+//	        last_rollout: "{{ rollout_id }}"
+//	      status_code: 204
+//
+// The outputs posted by a run are merged with the outputs of the previous
+// runs, an output posted as null being removed, and are passed to the next
+// run as ansible_operator_previous_outputs.
+package outputs
+
+import (
+	"sync"
+)
+
+// Tracker - collects the outputs of the runs it was started for. A nil
+// Tracker collects nothing.
+type Tracker struct {
+	mutex sync.Mutex
+	runs  map[string]map[string]interface{}
+}
+
+// NewTracker - creates a tracker.
+func NewTracker() *Tracker {
+	return &Tracker{runs: map[string]map[string]interface{}{}}
+}
+
+// Start - starts collecting the outputs of the run of ident.
+func (t *Tracker) Start(ident string) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.runs[ident] = map[string]interface{}{}
+}
+
+// Set - sets outputs of the run of ident, over the ones it set before. It
+// returns false if the outputs of the run are not collected.
+func (t *Tracker) Set(ident string, outputs map[string]interface{}) bool {
+	if t == nil {
+		return false
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	set, ok := t.runs[ident]
+	if !ok {
+		return false
+	}
+	for k, v := range outputs {
+		set[k] = v
+	}
+	return true
+}
+
+// Stop - stops collecting the outputs of the run of ident, and returns them.
+func (t *Tracker) Stop(ident string) map[string]interface{} {
+	if t == nil {
+		return nil
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	set := t.runs[ident]
+	delete(t.runs, ident)
+	return set
+}
@@ -0,0 +1,58 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outputs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTracker(t *testing.T) {
+	tracker := NewTracker()
+	if tracker.Set("1", map[string]interface{}{"revision": "v0"}) {
+		t.Fatalf("Outputs set before the run started")
+	}
+
+	tracker.Start("1")
+	for _, set := range []map[string]interface{}{
+		{"revision": "v1", "replicas": float64(3)},
+		{"revision": "v2", "stale": nil},
+	} {
+		if !tracker.Set("1", set) {
+			t.Fatalf("Outputs %v not set", set)
+		}
+	}
+	expected := map[string]interface{}{"revision": "v2", "replicas": float64(3), "stale": nil}
+	if got := tracker.Stop("1"); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("Unexpected outputs\nexpected: %v\nactual: %v", expected, got)
+	}
+	if tracker.Set("1", map[string]interface{}{"revision": "v3"}) {
+		t.Fatalf("Outputs set after the run stopped")
+	}
+	if got := tracker.Stop("1"); got != nil {
+		t.Fatalf("Unexpected outputs %v after the run stopped", got)
+	}
+}
+
+func TestNilTracker(t *testing.T) {
+	var tracker *Tracker
+	tracker.Start("1")
+	if tracker.Set("1", map[string]interface{}{"revision": "v1"}) {
+		t.Fatalf("Outputs set on a nil tracker")
+	}
+	if got := tracker.Stop("1"); got != nil {
+		t.Fatalf("Unexpected outputs %v", got)
+	}
+}
@@ -17,6 +17,7 @@
 package paramconv
 
 import (
+	"fmt"
 	"regexp"
 	"strings"
 
@@ -32,15 +33,80 @@ var (
 		"url":  "URL",
 		"ip":   "IP",
 	}
+
+	defaultConverter = NewConverter(Rules{})
 )
 
+// Rules - customizes how keys are converted between camelCase and snake_case.
+type Rules struct {
+	// WordMappings maps a lower case word to the form it takes in camelCase,
+	// e.g. "ssl": "SSL". They are added to the built-in mappings for HTTP, URL
+	// and IP.
+	WordMappings map[string]string `yaml:"wordMappings"`
+	// PreserveKeys are kept verbatim, along with every key nested under them.
+	PreserveKeys []string `yaml:"preserveKeys"`
+	// MaxDepth is the number of levels of nested keys that are converted.
+	// Deeper keys are kept verbatim. Zero means no limit.
+	MaxDepth int `yaml:"maxDepth"`
+	// LowerLeadingWord keeps the word leading a camelCase key lower case even
+	// when it is mapped, e.g. ssl_ca_data becomes sslCAData rather than
+	// SSLCAData.
+	LowerLeadingWord bool `yaml:"lowerLeadingWord"`
+}
+
+// Validate - ensures that every word mapping is a single lower case word
+// mapped to a different casing of itself, and that MaxDepth is not negative.
+func (r Rules) Validate() error {
+	if r.MaxDepth < 0 {
+		return fmt.Errorf("maxDepth must not be negative, got %d", r.MaxDepth)
+	}
+	for word, mapped := range r.WordMappings {
+		if word == "" || word != strings.ToLower(word) || strings.ContainsAny(word, "_- ") {
+			return fmt.Errorf("word mapping %q must be a single lower case word", word)
+		}
+		if strings.ToLower(mapped) != word {
+			return fmt.Errorf("word mapping %q: %q is not a casing of the word", word, mapped)
+		}
+	}
+	return nil
+}
+
+// Converter - converts keys between camelCase and snake_case following a set
+// of Rules, in both directions.
+type Converter struct {
+	wordMapping  map[string]string
+	preserveKeys map[string]bool
+	maxDepth     int
+	lowerLeading bool
+}
+
+// NewConverter - returns a Converter for the given rules.
+func NewConverter(rules Rules) *Converter {
+	c := &Converter{
+		wordMapping:  map[string]string{},
+		preserveKeys: map[string]bool{},
+		maxDepth:     rules.MaxDepth,
+		lowerLeading: rules.LowerLeadingWord,
+	}
+	for k, v := range wordMapping {
+		c.wordMapping[k] = v
+	}
+	for k, v := range rules.WordMappings {
+		c.wordMapping[k] = v
+	}
+	for _, k := range rules.PreserveKeys {
+		c.preserveKeys[k] = true
+	}
+	return c
+}
+
 func addWordBoundariesToNumbers(s string) string {
 	b := []byte(s)
 	b = numberSequence.ReplaceAll(b, numberReplacement)
 	return string(b)
 }
 
-func translateWord(word string, initCase bool) string {
+func translateWord(wordMapping map[string]string, word string, initCase bool) string {
 	if val, ok := wordMapping[word]; ok {
 		return val
 	}
@@ -53,6 +119,11 @@ func translateWord(word string, initCase bool) string {
 
 // Converts a string to CamelCase
 func ToCamel(s string) string {
+	return defaultConverter.ToCamel(s)
+}
+
+// ToCamel - converts a string to CamelCase using the converter's word mappings.
+func (c *Converter) ToCamel(s string) string {
 	s = addWordBoundariesToNumbers(s)
 	s = strings.Trim(s, " ")
 	n := ""
@@ -69,7 +140,11 @@ func ToCamel(s string) string {
 
 	ret := ""
 	for i, substr := range bits {
-		ret += translateWord(substr, i != 0)
+		if i == 0 && c.lowerLeading {
+			ret += substr
+			continue
+		}
+		ret += translateWord(c.wordMapping, substr, i != 0)
 	}
 	return ret
 }
@@ -81,7 +156,7 @@ func ToCamel(s string) string {
 // which contains is a special word "IP", the function will return "egress_IP".
 // If the last character of the special word is an "s" (i.e plural of the word
 // found in wordMapping), it is considered a part of that word and will be capitalized.
-func preprocessWordMapping(value string) string {
+func preprocessWordMapping(wordMapping map[string]string, value string) string {
 	for _, word := range wordMapping {
 		idx := strings.Index(value, word)
 		if idx >= 0 {
@@ -111,6 +186,11 @@ func preprocessWordMapping(value string) string {
 
 // Converts a string to snake_case
 func ToSnake(s string) string {
+	return defaultConverter.ToSnake(s)
+}
+
+// ToSnake - converts a string to snake_case using the converter's word mappings.
+func (c *Converter) ToSnake(s string) string {
 	s = addWordBoundariesToNumbers(s)
 	s = strings.Trim(s, " ")
 	var prefix string
@@ -125,7 +205,7 @@ func ToSnake(s string) string {
 	iReal := -1
 
 	// append underscore (_) as prefix and postfix to isolate special words defined in the wordMapping
-	s = preprocessWordMapping(s)
+	s = preprocessWordMapping(c.wordMapping, s)
 
 	for i, v := range s {
 		iReal++
@@ -162,47 +242,70 @@ func ToSnake(s string) string {
 	joined := strings.Join(bits, "_")
 
 	// prepending an underscore (_) if the word begins with a Capital Letter
-	if _, ok := wordMapping[bits[0]]; !ok {
+	if _, ok := c.wordMapping[bits[0]]; !ok {
 		return prefix + joined
 	}
 	return joined
 }
 
-func convertParameter(fn func(string) string, v interface{}) interface{} {
+func (c *Converter) convertParameter(fn func(string) string, v interface{}, depth int) interface{} {
 	switch v := v.(type) {
 	case map[string]interface{}:
-		ret := map[string]interface{}{}
-		for key, val := range v {
-			ret[fn(key)] = convertParameter(fn, val)
-		}
-		return ret
+		return c.convertMapKeys(fn, v, depth)
 	case []interface{}:
-		return convertArray(fn, v)
+		return c.convertArray(fn, v, depth)
 	default:
 		return v
 	}
 }
 
-func convertArray(fn func(string) string, in []interface{}) []interface{} {
+func (c *Converter) convertArray(fn func(string) string, in []interface{}, depth int) []interface{} {
 	res := make([]interface{}, len(in))
 	for i, v := range in {
-		res[i] = convertParameter(fn, v)
+		res[i] = c.convertParameter(fn, v, depth)
 	}
 	return res
 }
 
-func convertMapKeys(fn func(string) string, in map[string]interface{}) map[string]interface{} {
+// convertMapKeys converts the keys of in, which sit at the given depth (1 for
+// the top level), and the keys nested under them.
+func (c *Converter) convertMapKeys(fn func(string) string, in map[string]interface{}, depth int) map[string]interface{} {
+	if c.maxDepth > 0 && depth > c.maxDepth {
+		return in
+	}
 	converted := map[string]interface{}{}
 	for key, val := range in {
-		converted[fn(key)] = convertParameter(fn, val)
+		if c.preserveKeys[key] {
+			converted[key] = val
+			continue
+		}
+		converted[fn(key)] = c.convertParameter(fn, val, depth+1)
 	}
 	return converted
 }
 
 func MapToSnake(in map[string]interface{}) map[string]interface{} {
-	return convertMapKeys(ToSnake, in)
+	return defaultConverter.MapToSnake(in)
 }
 
 func MapToCamel(in map[string]interface{}) map[string]interface{} {
-	return convertMapKeys(ToCamel, in)
+	return defaultConverter.MapToCamel(in)
+}
+
+// MapToSnake - converts the keys of in to snake_case following the converter's
+// rules. A nil Converter uses the default rules.
+func (c *Converter) MapToSnake(in map[string]interface{}) map[string]interface{} {
+	if c == nil {
+		c = defaultConverter
+	}
+	return c.convertMapKeys(c.ToSnake, in, 1)
+}
+
+// MapToCamel - converts the keys of in to camelCase following the converter's
+// rules. It reverses MapToSnake.
+func (c *Converter) MapToCamel(in map[string]interface{}) map[string]interface{} {
+	if c == nil {
+		c = defaultConverter
+	}
+	return c.convertMapKeys(c.ToCamel, in, 1)
 }
@@ -122,6 +122,21 @@ func TestToCamel(t *testing.T) {
 			args: args{"egressIPs"},
 			want: "egressIPs",
 		},
+		{
+			name: "should map a leading special word",
+			args: args{"http_server"},
+			want: "HTTPServer",
+		},
+		{
+			name: "should map a leading special word followed by a word",
+			args: args{"ip_address"},
+			want: "IPAddress",
+		},
+		{
+			name: "should map a single special word",
+			args: args{"url"},
+			want: "URL",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -240,3 +255,98 @@ func TestToSnake(t *testing.T) {
 		})
 	}
 }
+
+func TestConverter(t *testing.T) {
+	c := NewConverter(Rules{
+		WordMappings: map[string]string{
+			"ssl": "SSL",
+			"ca":  "CA",
+			"api": "API",
+		},
+		PreserveKeys:     []string{"nodeSelector"},
+		MaxDepth:         2,
+		LowerLeadingWord: true,
+	})
+
+	camel := map[string]interface{}{
+		"sslCAData":     "value",
+		"k8sAPIVersion": "value",
+		"nodeSelector": map[string]interface{}{
+			"app.kubernetes.io/name": "value",
+		},
+		"serverConfig": map[string]interface{}{
+			"listenURL": "value",
+			"tlsConfig": map[string]interface{}{
+				"caBundle": "value",
+			},
+		},
+	}
+	snake := map[string]interface{}{
+		"ssl_ca_data":     "value",
+		"k8s_api_version": "value",
+		"nodeSelector": map[string]interface{}{
+			"app.kubernetes.io/name": "value",
+		},
+		"server_config": map[string]interface{}{
+			"listen_url": "value",
+			"tls_config": map[string]interface{}{
+				"caBundle": "value",
+			},
+		},
+	}
+
+	if got := c.MapToSnake(camel); !reflect.DeepEqual(got, snake) {
+		t.Errorf("MapToSnake() = %v, want %v", got, snake)
+	}
+	if got := c.MapToCamel(snake); !reflect.DeepEqual(got, camel) {
+		t.Errorf("MapToCamel() = %v, want %v", got, camel)
+	}
+}
+
+func TestConverterToCamel(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules Rules
+		s     string
+		want  string
+	}{
+		{name: "leading custom word", rules: Rules{WordMappings: map[string]string{"ssl": "SSL"}},
+			s: "ssl_ca_data", want: "SSLCaData"},
+		{name: "leading special word", rules: Rules{WordMappings: map[string]string{"ssl": "SSL"}},
+			s: "http_server", want: "HTTPServer"},
+		{name: "lower leading custom word", rules: Rules{LowerLeadingWord: true,
+			WordMappings: map[string]string{"ssl": "SSL"}}, s: "ssl_ca_data", want: "sslCaData"},
+		{name: "lower leading special word", rules: Rules{LowerLeadingWord: true}, s: "http_server", want: "httpServer"},
+		{name: "lower single special word", rules: Rules{LowerLeadingWord: true}, s: "url", want: "url"},
+		{name: "trailing special word", rules: Rules{LowerLeadingWord: true}, s: "listen_url", want: "listenURL"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewConverter(tt.rules).ToCamel(tt.s); got != tt.want {
+				t.Errorf("ToCamel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRulesValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   Rules
+		wantErr bool
+	}{
+		{name: "empty"},
+		{name: "valid", rules: Rules{WordMappings: map[string]string{"ssl": "SSL"}, MaxDepth: 3}},
+		{name: "negative depth", rules: Rules{MaxDepth: -1}, wantErr: true},
+		{name: "upper case word", rules: Rules{WordMappings: map[string]string{"SSL": "SSL"}}, wantErr: true},
+		{name: "multiple words", rules: Rules{WordMappings: map[string]string{"ca_data": "CAData"}}, wantErr: true},
+		{name: "different word", rules: Rules{WordMappings: map[string]string{"ssl": "TLS"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rules.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package audit records the API requests the runs make through the proxy,
+// one JSON record per request, to tell which custom resource, and which run
+// of it, caused which API calls.
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Record - an API request made through the proxy.
+type Record struct {
+	Time time.Time `json:"time"`
+	// Ident is the job ident of the run that made the request.
+	Ident string `json:"ident,omitempty"`
+	// Owner is the custom resource the run reconciles.
+	Owner *Owner `json:"owner,omitempty"`
+	// User is the user the request was made as, if impersonated.
+	User        string `json:"user,omitempty"`
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	Verb        string `json:"verb,omitempty"`
+	APIGroup    string `json:"apiGroup,omitempty"`
+	APIVersion  string `json:"apiVersion,omitempty"`
+	Resource    string `json:"resource,omitempty"`
+	Subresource string `json:"subresource,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Code        int    `json:"code"`
+	// Cache is HIT if the request was served from the cache of the proxy,
+	// MISS if by the API server, and unset if the request did not reach the
+	// cache, e.g. because it is disabled or the request was rejected.
+	Cache string `json:"cache,omitempty"`
+	// Latency is how long the request took to be served, in seconds.
+	Latency float64 `json:"latency"`
+	// Body is the redacted body of the request, if bodies are recorded.
+	Body string `json:"body,omitempty"`
+}
+
+// Owner - the custom resource a run reconciles.
+type Owner struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+const (
+	// CacheHit - the request was served from the cache of the proxy.
+	CacheHit = "HIT"
+	// CacheMiss - the request was served by the API server.
+	CacheMiss = "MISS"
+)
+
+// Sink - where the records are written, as JSON lines. A nil Sink records
+// nothing.
+type Sink struct {
+	mutex sync.Mutex
+	w     io.Writer
+	// file is set when writing to a file, rotated once it would grow past
+	// maxSize, if positive, keeping maxBackups rotated files.
+	file       *os.File
+	path       string
+	size       int64
+	maxSize    int64
+	maxBackups int
+}
+
+// NewSink - creates a sink writing the records to the file at path, or to
+// stdout if path is "-". The file is rotated once it would grow past
+// maxSize bytes, if positive, to path.1, path.2 and so on, keeping
+// maxBackups rotated files.
+func NewSink(path string, maxSize int64, maxBackups int) (*Sink, error) {
+	if path == "-" {
+		return &Sink{w: os.Stdout}, nil
+	}
+	s := &Sink{path: path, maxSize: maxSize, maxBackups: maxBackups}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Write - writes r.
+func (s *Sink) Write(r Record) error {
+	if s == nil {
+		return nil
+	}
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.file != nil && s.maxSize > 0 && s.size > 0 && s.size+int64(len(line)) > s.maxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+	n, err := s.w.Write(line)
+	s.size += int64(n)
+	return err
+}
+
+// Close - closes the file the records are written to, if any.
+func (s *Sink) Close() error {
+	if s == nil {
+		return nil
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.file == nil {
+		return nil
+	}
+	return s.file.Close()
+}
+
+// open opens the file at the path of the sink, to append to it.
+func (s *Sink) open() error {
+	file, err := os.OpenFile(s.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open the audit log: %w", err)
+	}
+	fi, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to open the audit log: %w", err)
+	}
+	s.file, s.w, s.size = file, file, fi.Size()
+	return nil
+}
+
+// rotate shifts the rotated files, dropping the oldest, and reopens the file
+// at the path of the sink.
+func (s *Sink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+	if s.maxBackups < 1 {
+		if err := os.Remove(s.path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return s.open()
+	}
+	for i := s.maxBackups - 1; i > 0; i-- {
+		err := os.Rename(fmt.Sprintf("%s.%d", s.path, i), fmt.Sprintf("%s.%d", s.path, i+1))
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	if err := os.Rename(s.path, s.path+".1"); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return s.open()
+}
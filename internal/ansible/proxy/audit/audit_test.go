@@ -0,0 +1,206 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/metrics"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/cacheoutcome"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/credentials"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/kubeconfig"
+)
+
+func readRecords(t *testing.T, path string) []Record {
+	t.Helper()
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	var records []Record
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, r)
+	}
+	return records
+}
+
+func TestSinkRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	record := Record{Method: "GET", Path: "/api/v1/namespaces/default/pods", Code: 200}
+	line, err := json.Marshal(record)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// two records fit in the file.
+	sink, err := NewSink(path, int64(2*(len(line)+1)), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 7; i++ {
+		if err := sink.Write(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	for file, expected := range map[string]int{path: 1, path + ".1": 2, path + ".2": 2} {
+		if n := len(readRecords(t, file)); n != expected {
+			t.Fatalf("Unexpected number of records %d in %s, expected %d", n, file, expected)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Fatalf("Expected only 2 rotated files, got %v", err)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	sink, err := NewSink(path, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// GETs are served from the "cache", the other requests reach the API
+	// server.
+	apiServer := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		if req.Method == http.MethodPost && string(body) != `{"kind":"ConfigMap"}` {
+			t.Errorf("Unexpected body %q reaching the API server", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+	cache := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if req.Method == http.MethodGet {
+				cacheoutcome.Set(req.Context(), metrics.CacheHit)
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+			cacheoutcome.Set(req.Context(), metrics.CacheSkip)
+			next.ServeHTTP(w, req)
+		})
+	}
+	h := Handler(Identify(cache(apiServer)), sink, HandlerOptions{
+		RedactBody: func([]byte) string { return "redacted" },
+	})
+
+	c := credentials.Credential{
+		Owner: kubeconfig.NamespacedOwnerReference{
+			OwnerReference: metav1.OwnerReference{APIVersion: "cache.example.com/v1alpha1", Kind: "Memcached",
+				Name: "example"},
+			Namespace: "default",
+		},
+		Ident:       "1234",
+		Impersonate: "system:serviceaccount:default:runner",
+	}
+	for _, req := range []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/default/configmaps/example", nil),
+		httptest.NewRequest(http.MethodPost, "/api/v1/namespaces/default/configmaps",
+			strings.NewReader(`{"kind":"ConfigMap"}`)),
+	} {
+		h.ServeHTTP(httptest.NewRecorder(), req.WithContext(credentials.WithCredential(req.Context(), c)))
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	records := readRecords(t, path)
+	if len(records) != 2 {
+		t.Fatalf("Unexpected number of records %d", len(records))
+	}
+	for i, expected := range []Record{
+		{Method: "GET", Verb: "get", Resource: "configmaps", Namespace: "default", Name: "example", Code: 200,
+			Cache: CacheHit},
+		{Method: "POST", Verb: "create", Resource: "configmaps", Namespace: "default", Code: 201,
+			Cache: CacheMiss, Body: "redacted"},
+	} {
+		r := records[i]
+		if r.Method != expected.Method || r.Verb != expected.Verb || r.Resource != expected.Resource ||
+			r.Namespace != expected.Namespace || r.Name != expected.Name || r.Code != expected.Code ||
+			r.Cache != expected.Cache || r.Body != expected.Body {
+			t.Fatalf("Unexpected record %+v, expected %+v", r, expected)
+		}
+		if r.Ident != "1234" || r.User != c.Impersonate || r.Owner == nil ||
+			*r.Owner != (Owner{APIVersion: "cache.example.com/v1alpha1", Kind: "Memcached", Namespace: "default",
+				Name: "example"}) {
+			t.Fatalf("Unexpected run of the record %+v", r)
+		}
+	}
+}
+
+func TestHandlerUnauthenticated(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	sink, err := NewSink(path, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the requests without a credential are rejected before being
+	// identified.
+	authenticate := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if _, ok := credentials.FromContext(req.Context()); !ok {
+				http.Error(w, "Unauthorized", http.StatusUnauthorized)
+				return
+			}
+			next.ServeHTTP(w, req)
+		})
+	}
+	apiServer := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	h := Handler(authenticate(Identify(apiServer)), sink, HandlerOptions{})
+	h.ServeHTTP(httptest.NewRecorder(),
+		httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/default/configmaps/example", nil))
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	records := readRecords(t, path)
+	if len(records) != 1 {
+		t.Fatalf("Unexpected number of records %d", len(records))
+	}
+	if r := records[0]; r.Code != http.StatusUnauthorized || r.Ident != "" || r.Owner != nil || r.User != "" ||
+		r.Cache != "" || r.Verb != "get" || r.Name != "example" {
+		t.Fatalf("Unexpected record %+v", r)
+	}
+}
+
+func TestNilSink(t *testing.T) {
+	var sink *Sink
+	if err := sink.Write(Record{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
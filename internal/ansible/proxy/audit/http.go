@@ -0,0 +1,115 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/felixge/httpsnoop"
+	"k8s.io/utils/set"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/metrics"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/cacheoutcome"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/credentials"
+	k8sRequest "github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/requestfactory"
+)
+
+var log = logf.Log.WithName("audit")
+
+// HandlerOptions - how the requests are recorded.
+type HandlerOptions struct {
+	// RedactBody redacts the body of a request, which is recorded if set.
+	RedactBody func([]byte) string
+}
+
+type recordKey struct{}
+
+// Handler - records the requests served by next to sink, including those
+// rejected before being authenticated. The run making a request is recorded
+// by Identify, which next must reach once the request is authenticated, and
+// the cache outcome by the cache handler, if any.
+func Handler(next http.Handler, sink *Sink, options HandlerOptions) http.Handler {
+	rf := k8sRequest.RequestInfoFactory{APIPrefixes: set.New("api", "apis"),
+		GrouplessAPIPrefixes: set.New("api")}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r := &Record{
+			Time:   time.Now(),
+			Method: req.Method,
+			Path:   req.URL.Path,
+		}
+		if info, err := rf.NewRequestInfo(req); err == nil {
+			r.Verb = info.Verb
+			r.APIGroup = info.APIGroup
+			r.APIVersion = info.APIVersion
+			r.Resource = info.Resource
+			r.Subresource = info.Subresource
+			r.Namespace = info.Namespace
+			r.Name = info.Name
+		}
+		if options.RedactBody != nil && req.Body != nil {
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				log.Error(err, "Could not read request body")
+			}
+			req.Body = io.NopCloser(bytes.NewBuffer(body))
+			if len(body) > 0 {
+				r.Body = options.RedactBody(body)
+			}
+		}
+		ctx := cacheoutcome.WithOutcome(context.WithValue(req.Context(), recordKey{}, r))
+		m := httpsnoop.CaptureMetrics(next, w, req.WithContext(ctx))
+		// the requests not reaching the cache handler, such as those rejected
+		// before being served, are neither hits nor misses.
+		switch cacheoutcome.FromContext(ctx) {
+		case "":
+		case metrics.CacheHit:
+			r.Cache = CacheHit
+		default:
+			r.Cache = CacheMiss
+		}
+		r.Code = m.Code
+		r.Latency = m.Duration.Seconds()
+		if err := sink.Write(*r); err != nil {
+			log.Error(err, "Failed to write the audit record", "method", r.Method, "path", r.Path)
+		}
+	})
+}
+
+// Identify - records the run making the requests reaching next, from the
+// credential they were authenticated with.
+func Identify(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if r, ok := req.Context().Value(recordKey{}).(*Record); ok {
+			if c, ok := credentials.FromContext(req.Context()); ok {
+				r.Ident = c.Ident
+				r.User = c.Impersonate
+				if c.Owner.Kind != "" {
+					r.Owner = &Owner{
+						APIVersion: c.Owner.APIVersion,
+						Kind:       c.Owner.Kind,
+						Namespace:  c.Owner.Namespace,
+						Name:       c.Owner.Name,
+					}
+				}
+			}
+		}
+		next.ServeHTTP(w, req)
+	})
+}
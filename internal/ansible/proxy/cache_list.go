@@ -0,0 +1,157 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// continueTokenVersion - the version of the continue tokens, as encoded by
+// the API server.
+const continueTokenVersion = "meta.k8s.io/v1"
+
+// continueToken - the continue token of a list, encoded the way the API
+// server encodes it, so that a list started from the cache can be continued
+// by the API server and the other way around.
+type continueToken struct {
+	APIVersion      string `json:"v"`
+	ResourceVersion int64  `json:"rv"`
+	StartKey        string `json:"start"`
+}
+
+// encodeContinue encodes the token continuing a list from startKey.
+func encodeContinue(startKey string, resourceVersion int64) (string, error) {
+	data, err := json.Marshal(continueToken{APIVersion: continueTokenVersion, ResourceVersion: resourceVersion,
+		StartKey: startKey})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodeContinue returns the key a list continues from.
+func decodeContinue(value string) (string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return "", fmt.Errorf("continue key is not valid: %w", err)
+	}
+	var token continueToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return "", fmt.Errorf("continue key is not valid: %w", err)
+	}
+	if token.APIVersion != continueTokenVersion {
+		return "", fmt.Errorf("continue key is not valid: unrecognized version %q", token.APIVersion)
+	}
+	if token.StartKey == "" {
+		return "", errors.New("continue key is not valid: empty start key")
+	}
+	// the key is relative to the resources listed, as cleaned by the API
+	// server.
+	key := "/" + strings.TrimPrefix(token.StartKey, "/")
+	if path.Clean(key) != key {
+		return "", fmt.Errorf("continue key is not valid: %s", token.StartKey)
+	}
+	return key[1:], nil
+}
+
+// listKey returns the key of an object in a list of the resources of
+// namespace, all namespaces if empty, ordered as the API server orders them.
+func listKey(namespace string, obj *unstructured.Unstructured) string {
+	if namespace != "" || obj.GetNamespace() == "" {
+		return obj.GetName()
+	}
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
+
+// informerGetter - gets the informer of a resource from a cache.
+type informerGetter interface {
+	GetInformer(ctx context.Context, obj client.Object, opts ...cache.InformerGetOption) (cache.Informer, error)
+}
+
+// informerResourceVersion returns the resourceVersion the informer of gvk last
+// listed or watched the resources at, which a list from the cache is as
+// recent as. An error is returned if it is not known, or not a number the
+// continue tokens can hold.
+func informerResourceVersion(ctx context.Context, informers informerGetter, gvk schema.GroupVersionKind) (int64,
+	error) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	informer, err := informers.GetInformer(ctx, u)
+	if err != nil {
+		return 0, err
+	}
+	versioned, ok := informer.(interface{ LastSyncResourceVersion() string })
+	if !ok {
+		return 0, fmt.Errorf("the informer of %s does not report its resourceVersion", gvk)
+	}
+	resourceVersion, err := strconv.ParseInt(versioned.LastSyncResourceVersion(), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse the resourceVersion of the informer of %s: %w", gvk, err)
+	}
+	return resourceVersion, nil
+}
+
+// paginateList sets the resourceVersion of list, the one of the informer it
+// was listed from, and keeps the page of at most limit items, if positive,
+// starting from the continue token, if set. The token of the next page is set
+// on list, if any. The pages are taken from the cache as it is when each is
+// requested, rather than from a consistent snapshot of the list.
+func paginateList(list *unstructured.UnstructuredList, namespace string, resourceVersion int64, limit int64,
+	continueValue string) error {
+	list.SetContinue("")
+	list.SetRemainingItemCount(nil)
+	list.SetResourceVersion(strconv.FormatInt(resourceVersion, 10))
+
+	items := list.Items
+	sort.Slice(items, func(i, j int) bool {
+		return listKey(namespace, &items[i]) < listKey(namespace, &items[j])
+	})
+	if continueValue != "" {
+		startKey, err := decodeContinue(continueValue)
+		if err != nil {
+			return err
+		}
+		start := sort.Search(len(items), func(i int) bool {
+			return listKey(namespace, &items[i]) >= startKey
+		})
+		items = items[start:]
+	}
+	if limit > 0 && int64(len(items)) > limit {
+		// the next page starts right after the last item of this page.
+		next, err := encodeContinue(listKey(namespace, &items[limit-1])+"\x00", resourceVersion)
+		if err != nil {
+			return err
+		}
+		remaining := int64(len(items)) - limit
+		items = items[:limit]
+		list.SetContinue(next)
+		list.SetRemainingItemCount(&remaining)
+	}
+	list.Items = items
+	return nil
+}
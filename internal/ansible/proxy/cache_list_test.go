@@ -0,0 +1,156 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func newConfigMapList(keys ...[3]string) *unstructured.UnstructuredList {
+	list := &unstructured.UnstructuredList{}
+	for _, key := range keys {
+		item := unstructured.Unstructured{}
+		item.SetAPIVersion("v1")
+		item.SetKind("ConfigMap")
+		item.SetNamespace(key[0])
+		item.SetName(key[1])
+		item.SetResourceVersion(key[2])
+		list.Items = append(list.Items, item)
+	}
+	return list
+}
+
+func listNames(list *unstructured.UnstructuredList) []string {
+	var names []string
+	for _, item := range list.Items {
+		names = append(names, item.GetNamespace()+"/"+item.GetName())
+	}
+	return names
+}
+
+var _ = Describe("paginateList", func() {
+	It("Should set the resourceVersion and return the whole list without a limit", func() {
+		list := newConfigMapList([3]string{"default", "b", "12"}, [3]string{"default", "a", "30"})
+		list.SetContinue("continue-not-supported")
+		Expect(paginateList(list, "default", 42, 0, "")).To(Succeed())
+		Expect(listNames(list)).To(Equal([]string{"default/a", "default/b"}))
+		Expect(list.GetResourceVersion()).To(Equal("42"))
+		Expect(list.GetContinue()).To(BeEmpty())
+		Expect(list.GetRemainingItemCount()).To(BeNil())
+	})
+
+	It("Should paginate the list across namespaces", func() {
+		newList := func() *unstructured.UnstructuredList {
+			return newConfigMapList([3]string{"kube-system", "a", "1"}, [3]string{"default", "c", "2"},
+				[3]string{"default", "b", "3"}, [3]string{"default", "b-2", "4"}, [3]string{"other", "a", "5"})
+		}
+		var pages [][]string
+		continueValue := ""
+		for {
+			list := newList()
+			Expect(paginateList(list, "", 7, 2, continueValue)).To(Succeed())
+			Expect(list.GetResourceVersion()).To(Equal("7"))
+			pages = append(pages, listNames(list))
+			continueValue = list.GetContinue()
+			if continueValue == "" {
+				Expect(list.GetRemainingItemCount()).To(BeNil())
+				break
+			}
+			Expect(*list.GetRemainingItemCount()).To(BeNumerically(">", 0))
+		}
+		Expect(pages).To(Equal([][]string{
+			{"default/b", "default/b-2"},
+			{"default/c", "kube-system/a"},
+			{"other/a"},
+		}))
+	})
+
+	It("Should continue a list from a token of the API server", func() {
+		list := newConfigMapList([3]string{"default", "a", "1"}, [3]string{"default", "b", "2"},
+			[3]string{"default", "c", "3"})
+		token := base64.RawURLEncoding.EncodeToString([]byte(`{"v":"meta.k8s.io/v1","rv":2,"start":"a\u0000"}`))
+		Expect(paginateList(list, "default", 3, 1, token)).To(Succeed())
+		Expect(listNames(list)).To(Equal([]string{"default/b"}))
+
+		start, err := decodeContinue(list.GetContinue())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(start).To(Equal("b\x00"))
+	})
+
+	DescribeTable("Should reject invalid continue tokens",
+		func(token string) {
+			Expect(paginateList(newConfigMapList(), "default", 1, 1, token)).NotTo(Succeed())
+		},
+		Entry("not base64", "!"),
+		Entry("not JSON", base64.RawURLEncoding.EncodeToString([]byte("continue"))),
+		Entry("unknown version", base64.RawURLEncoding.EncodeToString([]byte(`{"v":"v2","rv":1,"start":"a"}`))),
+		Entry("empty start key", base64.RawURLEncoding.EncodeToString([]byte(`{"v":"meta.k8s.io/v1","rv":1}`))),
+		Entry("path traversal", base64.RawURLEncoding.EncodeToString(
+			[]byte(`{"v":"meta.k8s.io/v1","rv":1,"start":"../secrets"}`))),
+	)
+})
+
+// fakeInformer - an informer at a resourceVersion.
+type fakeInformer struct {
+	cache.Informer
+	resourceVersion string
+}
+
+func (i fakeInformer) LastSyncResourceVersion() string {
+	return i.resourceVersion
+}
+
+// fakeInformerGetter - gets the informer, or fails with err.
+type fakeInformerGetter struct {
+	informer cache.Informer
+	err      error
+}
+
+func (g fakeInformerGetter) GetInformer(context.Context, client.Object, ...cache.InformerGetOption) (cache.Informer,
+	error) {
+	return g.informer, g.err
+}
+
+var _ = Describe("informerResourceVersion", func() {
+	configMap := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+
+	It("Should return the resourceVersion of the informer", func() {
+		rv, err := informerResourceVersion(context.TODO(),
+			fakeInformerGetter{informer: fakeInformer{resourceVersion: "1234"}}, configMap)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rv).To(Equal(int64(1234)))
+	})
+
+	DescribeTable("Should fail without a resourceVersion the continue tokens can hold",
+		func(getter fakeInformerGetter) {
+			_, err := informerResourceVersion(context.TODO(), getter, configMap)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("no informer", fakeInformerGetter{err: errors.New("no informer")}),
+		Entry("not reported", fakeInformerGetter{informer: struct{ cache.Informer }{}}),
+		Entry("empty", fakeInformerGetter{informer: fakeInformer{}}),
+		Entry("not a number", fakeInformerGetter{informer: fakeInformer{resourceVersion: "a1b2"}}),
+	)
+})
@@ -22,6 +22,7 @@ import (
 	"net/http"
 	"regexp"
 	"strings"
+	"time"
 
 	libhandler "github.com/operator-framework/operator-lib/handler"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -36,8 +37,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/metrics"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/cacheoutcome"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/controllermap"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/credentials"
 	k8sRequest "github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/requestfactory"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/writes"
 )
 
 type marshaler interface {
@@ -54,9 +59,19 @@ type cacheResponseHandler struct {
 	injectOwnerRef    bool
 	apiResources      *apiResources
 	skipPathRegexp    []*regexp.Regexp
+	// writes are the writes of the runs, which the cache is waited for up to
+	// writeTimeout before serving their reads.
+	writes       *writes.Tracker
+	writeTimeout time.Duration
+	// lookups record the resources looked up, whose informers are removed
+	// once no longer used.
+	lookups *cacheLookups
 }
 
 func (c *cacheResponseHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	// the request is skipped unless looked up in the cache below.
+	outcome := metrics.CacheSkip
+	defer func() { cacheoutcome.Set(req.Context(), outcome) }()
 	switch req.Method {
 	case http.MethodGet:
 		// GET request means we need to check the cache
@@ -84,6 +99,7 @@ func (c *cacheResponseHandler) ServeHTTP(w http.ResponseWriter, req *http.Reques
 		if err != nil {
 			// break here in case resource doesn't exist in cache
 			log.Error(err, "Cache miss, can not find in rest mapper")
+			outcome = metrics.CacheMiss
 			break
 		}
 
@@ -97,14 +113,29 @@ func (c *cacheResponseHandler) ServeHTTP(w http.ResponseWriter, req *http.Reques
 		if err != nil {
 			// break here in case we can not understand if virtual resource or not
 			log.Error(err, "Unable to determine if virtual resource", "gvk", k)
+			outcome = metrics.CacheMiss
 			break
 		}
 
 		if isVR {
 			log.V(2).Info("Virtual resource, must ask the cluster API", "gvk", k)
+			outcome = metrics.CacheVirtual
 			break
 		}
 
+		// The lookups below start the informer of the resource, if not
+		// started yet.
+		c.lookups.touch(k)
+
+		// The cache may not have observed the writes of the run yet.
+		if !c.waitForWrites(req, r, k) {
+			log.V(1).Info("Cache has not observed the writes of the run, must ask the cluster API", "gvk", k)
+			outcome = metrics.CacheMiss
+			break
+		}
+		// the request is a miss unless served from the cache below.
+		outcome = metrics.CacheMiss
+
 		var m marshaler
 
 		log.V(2).Info("Get resource in our cache", "r", r)
@@ -131,6 +162,7 @@ func (c *cacheResponseHandler) ServeHTTP(w http.ResponseWriter, req *http.Reques
 
 		// Set Content-Type header
 		w.Header().Set("Content-Type", "application/json")
+		outcome = metrics.CacheHit
 		// Set X-Cache header to signal that response is served from Cache
 		w.Header().Set("X-Cache", "HIT")
 		if err := json.Indent(&i, resp, "", "  "); err != nil {
@@ -158,6 +190,12 @@ func (c *cacheResponseHandler) skipCacheLookup(r *k8sRequest.RequestInfo, gvk sc
 		return true
 	}
 
+	// the cache holds what the operator may read, which an impersonated
+	// user may not.
+	if cred, ok := credentials.FromContext(req.Context()); ok && cred.Impersonate != "" {
+		return true
+	}
+
 	owner, err := getRequestOwnerRef(req)
 	if err != nil {
 		log.Error(err, "Could not get owner reference from proxy.")
@@ -253,12 +291,12 @@ func (c *cacheResponseHandler) getListFromCache(r *k8sRequest.RequestInfo, req *
 		client.InNamespace(r.Namespace),
 	}
 	if k8sListOpts.LabelSelector != "" {
-		sel, err := labels.ConvertSelectorToLabelsMap(k8sListOpts.LabelSelector)
+		sel, err := labels.Parse(k8sListOpts.LabelSelector)
 		if err != nil {
-			log.Error(err, "Unable to convert label selectors for the client")
+			log.Error(err, "Unable to parse label selectors for the client")
 			return nil, err
 		}
-		clientListOpts = append(clientListOpts, client.MatchingLabels(sel))
+		clientListOpts = append(clientListOpts, client.MatchingLabelsSelector{Selector: sel})
 	}
 	if k8sListOpts.FieldSelector != "" {
 		sel, err := fields.ParseSelector(k8sListOpts.FieldSelector)
@@ -268,18 +306,30 @@ func (c *cacheResponseHandler) getListFromCache(r *k8sRequest.RequestInfo, req *
 		}
 		clientListOpts = append(clientListOpts, client.MatchingFieldsSelector{Selector: sel})
 	}
+	ctx, cancel := context.WithTimeout(context.Background(), cacheEstablishmentTimeout)
+	defer cancel()
+	// the resourceVersion of the list is the one of the informer before
+	// listing, which the items listed are at least as recent as.
+	resourceVersion, err := informerResourceVersion(ctx, c.informerCache, k)
+	if err != nil {
+		log.Info(fmt.Sprintf("cache miss: %v err-%v", k, err))
+		return nil, err
+	}
 	k.Kind = k.Kind + "List"
 	un := unstructured.UnstructuredList{}
 	un.SetGroupVersionKind(k)
-	ctx, cancel := context.WithTimeout(context.Background(), cacheEstablishmentTimeout)
-	defer cancel()
-	err := c.informerCache.List(ctx, &un, clientListOpts...)
+	err = c.informerCache.List(ctx, &un, clientListOpts...)
 	if err != nil {
 		// break here in case resource doesn't exist in cache but exists on APIserver
 		// This is very unlikely but provides user with expected 404
 		log.Info(fmt.Sprintf("cache miss: %v err-%v", k, err))
 		return nil, err
 	}
+	// the cache does not paginate, the whole list is paginated here.
+	if err := paginateList(&un, r.Namespace, resourceVersion, k8sListOpts.Limit, k8sListOpts.Continue); err != nil {
+		log.Error(err, "Unable to paginate the list from the cache")
+		return nil, err
+	}
 	return &un, nil
 }
 
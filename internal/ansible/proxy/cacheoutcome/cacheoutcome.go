@@ -0,0 +1,49 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cacheoutcome carries the cache outcome of the requests made
+// through the proxy, which the cache handler sets and the handlers wrapping
+// it read, to record the requests.
+package cacheoutcome
+
+import "context"
+
+type contextKey struct{}
+
+// WithOutcome - returns ctx carrying the cache outcome of a request. The
+// outcome already carried by ctx, if any, is kept, so that the handlers
+// wrapping each other read the same outcome.
+func WithOutcome(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(contextKey{}).(*string); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, new(string))
+}
+
+// Set - sets the cache outcome of the request, one of the metrics.Cache
+// outcomes, if ctx carries one.
+func Set(ctx context.Context, outcome string) {
+	if o, ok := ctx.Value(contextKey{}).(*string); ok {
+		*o = outcome
+	}
+}
+
+// FromContext - returns the cache outcome of the request, or "" if the
+// request did not reach the cache handler.
+func FromContext(ctx context.Context) string {
+	if o, ok := ctx.Value(contextKey{}).(*string); ok {
+		return *o
+	}
+	return ""
+}
@@ -16,9 +16,13 @@ package controllermap
 
 import (
 	"sync"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/redact"
 )
 
 // ControllerMap - map of GVK to ControllerMapContents
@@ -27,10 +31,25 @@ type ControllerMap struct {
 	internal map[schema.GroupVersionKind]*Contents
 }
 
-// WatchMap - map of GVK to interface. Determines if resource is being watched already
+// WatchMap - map of GVK to the watch of the resource. Determines if resource
+// is being watched already, and by which owners it is used.
 type WatchMap struct {
 	mutex    sync.RWMutex
-	internal map[schema.GroupVersionKind]interface{}
+	internal map[schema.GroupVersionKind]*watch
+}
+
+// watch - the owners using the watch of a resource, and since when it is
+// unused if none.
+type watch struct {
+	owners      map[types.NamespacedName]ownerUse
+	unusedSince time.Time
+}
+
+// ownerUse - the UID of an owner using a watch, and when it last referenced
+// the watch.
+type ownerUse struct {
+	uid        types.UID
+	referenced time.Time
 }
 
 // Contents - Contains internal data associated with each controller
@@ -41,6 +60,7 @@ type Contents struct {
 	OwnerWatchMap               *WatchMap
 	AnnotationWatchMap          *WatchMap
 	Blacklist                   map[schema.GroupVersionKind]bool
+	Redactor                    *redact.Redactor
 }
 
 // NewControllerMap returns a new object that contains a mapping between GVK
@@ -55,7 +75,7 @@ func NewControllerMap() *ControllerMap {
 // if resource is being watched
 func NewWatchMap() *WatchMap {
 	return &WatchMap{
-		internal: make(map[schema.GroupVersionKind]interface{}),
+		internal: make(map[schema.GroupVersionKind]*watch),
 	}
 }
 
@@ -88,12 +108,51 @@ func (cm *ControllerMap) Store(key schema.GroupVersionKind, value *Contents, bla
 	}
 }
 
+// Release - Releases the watches used by the owner, deleted from the
+// ControllerMap of each owner GVK
+func (cm *ControllerMap) Release(ownerGVK schema.GroupVersionKind, owner types.NamespacedName) {
+	if cm == nil {
+		return
+	}
+	contents, ok := cm.Get(ownerGVK)
+	if !ok {
+		return
+	}
+	contents.OwnerWatchMap.Release(owner)
+	contents.AnnotationWatchMap.Release(owner)
+}
+
+// Range - Calls f for each GVK to controller mapping
+func (cm *ControllerMap) Range(f func(key schema.GroupVersionKind, value *Contents)) {
+	cm.mutex.RLock()
+	defer cm.mutex.RUnlock()
+	for key, value := range cm.internal {
+		f(key, value)
+	}
+}
+
+// Watching - Checks if GVK is watched by a controller, either as its
+// primary resource or as a dependent resource used within gracePeriod
+func (cm *ControllerMap) Watching(key schema.GroupVersionKind, gracePeriod time.Duration) bool {
+	cm.mutex.RLock()
+	defer cm.mutex.RUnlock()
+	if _, ok := cm.internal[key]; ok {
+		return true
+	}
+	for _, value := range cm.internal {
+		if value.OwnerWatchMap.Used(key, gracePeriod) || value.AnnotationWatchMap.Used(key, gracePeriod) {
+			return true
+		}
+	}
+	return false
+}
+
 // Get - Checks if GVK is already watched
 func (wm *WatchMap) Get(key schema.GroupVersionKind) (value interface{}, ok bool) {
 	wm.mutex.RLock()
 	defer wm.mutex.RUnlock()
-	value, ok = wm.internal[key]
-	return value, ok
+	_, ok = wm.internal[key]
+	return nil, ok
 }
 
 // Delete - Deletes associated watches for a specific GVK
@@ -103,9 +162,115 @@ func (wm *WatchMap) Delete(key schema.GroupVersionKind) {
 	delete(wm.internal, key)
 }
 
-// Store - Adds a new GVK to be watched
+// Store - Adds a new GVK to be watched, unused until referenced
 func (wm *WatchMap) Store(key schema.GroupVersionKind) {
 	wm.mutex.Lock()
 	defer wm.mutex.Unlock()
-	wm.internal[key] = nil
+	wm.internal[key] = &watch{owners: map[types.NamespacedName]ownerUse{}, unusedSince: time.Now()}
+}
+
+// Reference - Records that the owner with the given UID uses the watch of
+// GVK, if watched
+func (wm *WatchMap) Reference(key schema.GroupVersionKind, owner types.NamespacedName, uid types.UID) {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+	if w, ok := wm.internal[key]; ok {
+		w.owners[owner] = ownerUse{uid: uid, referenced: time.Now()}
+	}
+}
+
+// Release - Records that the owner no longer uses any watch
+func (wm *WatchMap) Release(owner types.NamespacedName) {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+	for _, w := range wm.internal {
+		w.release(owner)
+	}
+}
+
+// ReleaseWatch - Records that the owner no longer uses the watch of GVK
+func (wm *WatchMap) ReleaseWatch(key schema.GroupVersionKind, owner types.NamespacedName) {
+	wm.mutex.Lock()
+	defer wm.mutex.Unlock()
+	if w, ok := wm.internal[key]; ok {
+		w.release(owner)
+	}
+}
+
+// Owners - Returns the owners using the watch of GVK that have not referenced
+// it since the given time, with their UIDs
+func (wm *WatchMap) Owners(key schema.GroupVersionKind,
+	referencedBefore time.Time) map[types.NamespacedName]types.UID {
+	wm.mutex.RLock()
+	defer wm.mutex.RUnlock()
+	w, ok := wm.internal[key]
+	if !ok {
+		return nil
+	}
+	var owners map[types.NamespacedName]types.UID
+	for owner, use := range w.owners {
+		if use.referenced.Before(referencedBefore) {
+			if owners == nil {
+				owners = map[types.NamespacedName]types.UID{}
+			}
+			owners[owner] = use.uid
+		}
+	}
+	return owners
+}
+
+// Keys - Returns the GVKs watched
+func (wm *WatchMap) Keys() []schema.GroupVersionKind {
+	wm.mutex.RLock()
+	defer wm.mutex.RUnlock()
+	keys := make([]schema.GroupVersionKind, 0, len(wm.internal))
+	for key := range wm.internal {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// release records that the owner no longer uses the watch.
+func (w *watch) release(owner types.NamespacedName) {
+	if _, ok := w.owners[owner]; !ok {
+		return
+	}
+	delete(w.owners, owner)
+	if len(w.owners) == 0 {
+		w.unusedSince = time.Now()
+	}
+}
+
+// unused returns whether no owner has used the watch for gracePeriod.
+func (w *watch) unused(gracePeriod time.Duration) bool {
+	return len(w.owners) == 0 && time.Since(w.unusedSince) >= gracePeriod
+}
+
+// Unused - Returns the GVKs of the watches no owner has used for
+// gracePeriod
+func (wm *WatchMap) Unused(gracePeriod time.Duration) []schema.GroupVersionKind {
+	wm.mutex.RLock()
+	defer wm.mutex.RUnlock()
+	var unused []schema.GroupVersionKind
+	for key, w := range wm.internal {
+		if w.unused(gracePeriod) {
+			unused = append(unused, key)
+		}
+	}
+	return unused
+}
+
+// Used - Checks if GVK is watched, and the watch was used within gracePeriod
+func (wm *WatchMap) Used(key schema.GroupVersionKind, gracePeriod time.Duration) bool {
+	wm.mutex.RLock()
+	defer wm.mutex.RUnlock()
+	w, ok := wm.internal[key]
+	return ok && !w.unused(gracePeriod)
+}
+
+// Len - the number of GVKs watched
+func (wm *WatchMap) Len() int {
+	wm.mutex.RLock()
+	defer wm.mutex.RUnlock()
+	return len(wm.internal)
 }
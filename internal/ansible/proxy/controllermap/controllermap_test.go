@@ -0,0 +1,118 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controllermap
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var (
+	ownerGVK  = schema.GroupVersionKind{Group: "cache.example.com", Version: "v1alpha1", Kind: "Memcached"}
+	configMap = schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	job       = schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"}
+)
+
+func TestWatchMapReferences(t *testing.T) {
+	first := types.NamespacedName{Namespace: "default", Name: "first"}
+	second := types.NamespacedName{Namespace: "default", Name: "second"}
+	wm := NewWatchMap()
+	wm.Store(configMap)
+	wm.Reference(configMap, first, "first-uid")
+	wm.Reference(configMap, second, "second-uid")
+	wm.Store(job)
+	wm.Reference(job, first, "first-uid")
+	// not watched.
+	wm.Reference(schema.GroupVersionKind{Version: "v1", Kind: "Secret"}, first, "first-uid")
+	if wm.Len() != 2 {
+		t.Fatalf("Unexpected number of watches %d", wm.Len())
+	}
+
+	if unused := wm.Unused(0); len(unused) != 0 {
+		t.Fatalf("Unexpected unused watches %v", unused)
+	}
+	wm.Release(first)
+	if unused := wm.Unused(time.Hour); len(unused) != 0 {
+		t.Fatalf("Unexpected unused watches %v within the grace period", unused)
+	}
+	if unused := wm.Unused(0); !reflect.DeepEqual(unused, []schema.GroupVersionKind{job}) {
+		t.Fatalf("Unexpected unused watches %v", unused)
+	}
+	if wm.Used(job, 0) {
+		t.Fatal("Expected the watch of jobs to be unused")
+	}
+	if !wm.Used(configMap, 0) {
+		t.Fatal("Expected the watch of config maps to be used")
+	}
+}
+
+func TestWatchMapOwners(t *testing.T) {
+	owner := types.NamespacedName{Namespace: "default", Name: "example"}
+	other := types.NamespacedName{Namespace: "default", Name: "other"}
+	wm := NewWatchMap()
+	wm.Store(configMap)
+	wm.Store(job)
+	wm.Reference(job, owner, "owner-uid")
+	wm.Reference(configMap, owner, "owner-uid")
+	before := time.Now()
+	wm.Reference(configMap, other, "other-uid")
+
+	if owners := wm.Owners(configMap, before); !reflect.DeepEqual(owners,
+		map[types.NamespacedName]types.UID{owner: "owner-uid"}) {
+		t.Fatalf("Unexpected owners %v", owners)
+	}
+	if owners := wm.Owners(schema.GroupVersionKind{Kind: "Secret", Version: "v1"}, time.Now()); owners != nil {
+		t.Fatalf("Unexpected owners of an unwatched resource %v", owners)
+	}
+
+	wm.ReleaseWatch(job, owner)
+	wm.ReleaseWatch(configMap, owner)
+	if unused := wm.Unused(0); !reflect.DeepEqual(unused, []schema.GroupVersionKind{job}) {
+		t.Fatalf("Unexpected unused watches %v", unused)
+	}
+	keys := wm.Keys()
+	if len(keys) != 2 {
+		t.Fatalf("Unexpected watched resources %v", keys)
+	}
+}
+
+func TestControllerMapWatching(t *testing.T) {
+	cm := NewControllerMap()
+	contents := &Contents{OwnerWatchMap: NewWatchMap(), AnnotationWatchMap: NewWatchMap()}
+	cm.Store(ownerGVK, contents, nil)
+	contents.AnnotationWatchMap.Store(job)
+	contents.AnnotationWatchMap.Reference(job, types.NamespacedName{Namespace: "default", Name: "example"}, "1234")
+
+	for gvk, expected := range map[schema.GroupVersionKind]bool{ownerGVK: true, job: true, configMap: false} {
+		if watching := cm.Watching(gvk, 0); watching != expected {
+			t.Fatalf("Unexpected watching %t of %v", watching, gvk)
+		}
+	}
+
+	cm.Release(ownerGVK, types.NamespacedName{Namespace: "default", Name: "example"})
+	if !cm.Watching(job, time.Hour) {
+		t.Fatal("Expected jobs to be watched within the grace period")
+	}
+	if cm.Watching(job, 0) {
+		t.Fatal("Expected jobs to be no longer watched")
+	}
+
+	var nilMap *ControllerMap
+	nilMap.Release(ownerGVK, types.NamespacedName{Namespace: "default", Name: "example"})
+}
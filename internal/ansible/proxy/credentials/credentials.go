@@ -0,0 +1,128 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package credentials issues the tokens the runs authenticate to the proxy
+// with. A token is bound to the owner of the run and its job ident, so that
+// only the run can make requests on behalf of the owner.
+package credentials
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"sync"
+	"time"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/kubeconfig"
+)
+
+// DefaultTTL - how long a token is valid by default, should its run never be
+// reported as done.
+const DefaultTTL = 24 * time.Hour
+
+// Credential - what a token was issued for.
+type Credential struct {
+	// Owner is the custom resource the run reconciles, which owns the
+	// resources created by the run.
+	Owner kubeconfig.NamespacedOwnerReference
+	// Ident is the job ident of the run.
+	Ident string
+	// TraceParent is the W3C traceparent of the run, if traced.
+	TraceParent string
+	// Impersonate is the user the requests of the run are made as, if
+	// set, rather than as the operator.
+	Impersonate string
+	// Expires is when the token expires.
+	Expires time.Time
+}
+
+// Store - the tokens issued and not yet revoked. A nil Store issues no
+// tokens.
+type Store struct {
+	ttl         time.Duration
+	mutex       sync.Mutex
+	credentials map[string]Credential
+}
+
+// NewStore - creates a store issuing tokens valid for ttl.
+func NewStore(ttl time.Duration) *Store {
+	return &Store{ttl: ttl, credentials: map[string]Credential{}}
+}
+
+// Issue - issues a new random token for c.
+func (s *Store) Issue(c Credential) (string, error) {
+	if s == nil {
+		return "", nil
+	}
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	now := time.Now()
+	c.Expires = now.Add(s.ttl)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for t, issued := range s.credentials {
+		if now.After(issued.Expires) {
+			delete(s.credentials, t)
+		}
+	}
+	s.credentials[token] = c
+	return token, nil
+}
+
+// Revoke - revokes a token.
+func (s *Store) Revoke(token string) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.credentials, token)
+}
+
+// Lookup - the credential of a token, unless the token is unknown, revoked
+// or expired.
+func (s *Store) Lookup(token string) (Credential, bool) {
+	if s == nil || token == "" {
+		return Credential{}, false
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	c, ok := s.credentials[token]
+	if !ok {
+		return Credential{}, false
+	}
+	if time.Now().After(c.Expires) {
+		delete(s.credentials, token)
+		return Credential{}, false
+	}
+	return c, true
+}
+
+type contextKey struct{}
+
+// WithCredential - returns ctx carrying the credential a request was
+// authenticated with.
+func WithCredential(ctx context.Context, c Credential) context.Context {
+	return context.WithValue(ctx, contextKey{}, c)
+}
+
+// FromContext - the credential a request was authenticated with, if any.
+func FromContext(ctx context.Context) (Credential, bool) {
+	c, ok := ctx.Value(contextKey{}).(Credential)
+	return c, ok
+}
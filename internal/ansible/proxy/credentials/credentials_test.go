@@ -0,0 +1,98 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package credentials
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/kubeconfig"
+)
+
+func TestStore(t *testing.T) {
+	s := NewStore(time.Hour)
+	owner := kubeconfig.NamespacedOwnerReference{
+		OwnerReference: metav1.OwnerReference{APIVersion: "cache.example.com/v1alpha1", Kind: "Memcached",
+			Name: "example", UID: "1234"},
+		Namespace: "default",
+	}
+	first, err := s.Issue(Credential{Owner: owner, Ident: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := s.Issue(Credential{Owner: owner, Ident: "2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first == "" || first == second {
+		t.Fatalf("Expected distinct tokens, got %q and %q", first, second)
+	}
+
+	c, ok := s.Lookup(first)
+	if !ok || c.Ident != "1" || c.Owner != owner || time.Until(c.Expires) <= 0 {
+		t.Fatalf("Unexpected credential %+v, %v", c, ok)
+	}
+	s.Revoke(first)
+	if _, ok := s.Lookup(first); ok {
+		t.Fatalf("Expected a revoked token to be rejected")
+	}
+	if _, ok := s.Lookup(second); !ok {
+		t.Fatalf("Expected revoking a token to keep the others")
+	}
+	for _, token := range []string{"", "unknown"} {
+		if _, ok := s.Lookup(token); ok {
+			t.Fatalf("Expected token %q to be rejected", token)
+		}
+	}
+}
+
+func TestStoreExpiry(t *testing.T) {
+	s := NewStore(-time.Second)
+	token, err := s.Issue(Credential{Ident: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := s.Lookup(token); ok {
+		t.Fatalf("Expected an expired token to be rejected")
+	}
+	if len(s.credentials) != 0 {
+		t.Fatalf("Expected the expired token to be forgotten")
+	}
+}
+
+func TestNilStore(t *testing.T) {
+	var s *Store
+	token, err := s.Issue(Credential{Ident: "1"})
+	if err != nil || token != "" {
+		t.Fatalf("Unexpected token %q, %v", token, err)
+	}
+	s.Revoke(token)
+	if _, ok := s.Lookup(token); ok {
+		t.Fatalf("Expected a nil store to reject every token")
+	}
+}
+
+func TestContext(t *testing.T) {
+	if _, ok := FromContext(context.Background()); ok {
+		t.Fatalf("Expected no credential in an empty context")
+	}
+	ctx := WithCredential(context.Background(), Credential{Ident: "1"})
+	if c, ok := FromContext(ctx); !ok || c.Ident != "1" {
+		t.Fatalf("Unexpected credential %+v, %v", c, ok)
+	}
+}
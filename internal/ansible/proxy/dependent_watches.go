@@ -0,0 +1,274 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/set"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	libhandler "github.com/operator-framework/operator-lib/handler"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/metrics"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/controllermap"
+)
+
+// maxDependentWatchCollectionInterval bounds how often the unused dependent
+// watches are looked for.
+const maxDependentWatchCollectionInterval = time.Minute
+
+// watchesMutex serializes the addition of the dependent watches and their
+// removal, so that a watch added back is not removed with the informer of
+// the watch it replaces.
+var watchesMutex sync.Mutex
+
+// informerRemover - removes the informer of a resource from a cache.
+type informerRemover interface {
+	RemoveInformer(ctx context.Context, obj client.Object) error
+}
+
+// cacheLookups - the resources looked up in the cache, whose informers the
+// lookups start, and when they were last looked up. A nil cacheLookups
+// records nothing.
+type cacheLookups struct {
+	mutex    sync.Mutex
+	lastUsed map[schema.GroupVersionKind]time.Time
+}
+
+// newCacheLookups - creates an empty record of the cache lookups.
+func newCacheLookups() *cacheLookups {
+	return &cacheLookups{lastUsed: map[schema.GroupVersionKind]time.Time{}}
+}
+
+// touch records that the resources of gvk are looked up in the cache.
+func (l *cacheLookups) touch(gvk schema.GroupVersionKind) {
+	if l == nil {
+		return
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.lastUsed[gvk] = time.Now()
+}
+
+// unused returns the GVKs of the resources not looked up for gracePeriod.
+func (l *cacheLookups) unused(gracePeriod time.Duration) []schema.GroupVersionKind {
+	if l == nil {
+		return nil
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	var unused []schema.GroupVersionKind
+	for gvk, lastUsed := range l.lastUsed {
+		if time.Since(lastUsed) >= gracePeriod {
+			unused = append(unused, gvk)
+		}
+	}
+	return unused
+}
+
+// used returns whether the resources of gvk were looked up within
+// gracePeriod.
+func (l *cacheLookups) used(gvk schema.GroupVersionKind, gracePeriod time.Duration) bool {
+	if l == nil {
+		return false
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	lastUsed, ok := l.lastUsed[gvk]
+	return ok && time.Since(lastUsed) < gracePeriod
+}
+
+// forget forgets the resources of gvk, unless they were looked up within
+// gracePeriod, e.g. while their informer was removed.
+func (l *cacheLookups) forget(gvk schema.GroupVersionKind, gracePeriod time.Duration) {
+	if l == nil {
+		return
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if lastUsed, ok := l.lastUsed[gvk]; ok && time.Since(lastUsed) >= gracePeriod {
+		delete(l.lastUsed, gvk)
+	}
+}
+
+// collectDependentWatches removes the dependent watches no owner has used,
+// and the informers of the resources not looked up in the cache, for
+// gracePeriod, until ctx is done. The dependents of the owners are listed
+// from dependents.
+func collectDependentWatches(ctx context.Context, cMap *controllermap.ControllerMap, lookups *cacheLookups,
+	dependents client.Reader, informers informerRemover, gracePeriod time.Duration) {
+	interval := min(gracePeriod, maxDependentWatchCollectionInterval)
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		releaseUnowned(ctx, cMap, dependents, gracePeriod)
+		removeUnusedWatches(ctx, cMap, lookups, informers, gracePeriod)
+	}, interval)
+}
+
+// releaseUnowned releases the watches of the owners that no longer own any
+// object of the watched resource in the cache. The owners that referenced
+// the watch within gracePeriod are kept, the cache possibly not having
+// observed the objects they just created yet. The objects of each resource
+// are listed once, for the owners of all the controllers.
+func releaseUnowned(ctx context.Context, cMap *controllermap.ControllerMap, dependents client.Reader,
+	gracePeriod time.Duration) {
+	referencedBefore := time.Now().Add(-gracePeriod)
+	watches := map[schema.GroupVersionKind][]ownerWatch{}
+	cMap.Range(func(ownerGVK schema.GroupVersionKind, contents *controllermap.Contents) {
+		for wm, byAnnotation := range map[*controllermap.WatchMap]bool{
+			contents.OwnerWatchMap:      false,
+			contents.AnnotationWatchMap: true,
+		} {
+			for _, gvk := range wm.Keys() {
+				if owners := wm.Owners(gvk, referencedBefore); len(owners) > 0 {
+					watches[gvk] = append(watches[gvk], ownerWatch{wm: wm, ownerGVK: ownerGVK,
+						byAnnotation: byAnnotation, owners: owners})
+				}
+			}
+		}
+	})
+
+	for gvk, ws := range watches {
+		owners, err := listOwners(ctx, dependents, gvk)
+		if err != nil {
+			log.Error(err, "Failed to list the dependents of the owners", "kind", gvk)
+			continue
+		}
+		for _, w := range ws {
+			for owner, uid := range w.owners {
+				owns := owners.uids.Has(uid)
+				if w.byAnnotation {
+					owns = owners.annotated.Has(annotatedOwner(w.ownerGVK.GroupKind().String(),
+						owner.Namespace+"/"+owner.Name))
+				}
+				if !owns {
+					log.V(1).Info("Releasing the watch of child resource no longer owned", "kind", gvk,
+						"owner_kind", w.ownerGVK, "owner", owner)
+					w.wm.ReleaseWatch(gvk, owner)
+				}
+			}
+		}
+	}
+}
+
+// ownerWatch - the owners of a kind using a dependent watch, by owner
+// reference or by annotation.
+type ownerWatch struct {
+	wm           *controllermap.WatchMap
+	ownerGVK     schema.GroupVersionKind
+	byAnnotation bool
+	owners       map[types.NamespacedName]types.UID
+}
+
+// dependentOwners - the owners of the objects of a resource.
+type dependentOwners struct {
+	// uids are the UIDs of the owner references of the objects.
+	uids set.Set[types.UID]
+	// annotated are the owners named by the owner annotations of the
+	// objects, as returned by annotatedOwner.
+	annotated set.Set[string]
+}
+
+// listOwners returns the owners of the objects of gvk in the cache.
+func listOwners(ctx context.Context, dependents client.Reader, gvk schema.GroupVersionKind) (dependentOwners, error) {
+	owners := dependentOwners{uids: set.New[types.UID](), annotated: set.New[string]()}
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := dependents.List(ctx, list); err != nil {
+		return owners, err
+	}
+	for _, u := range list.Items {
+		for _, ref := range u.GetOwnerReferences() {
+			owners.uids.Insert(ref.UID)
+		}
+		annotations := u.GetAnnotations()
+		if owner, ok := annotations[libhandler.NamespacedNameAnnotation]; ok {
+			owners.annotated.Insert(annotatedOwner(annotations[libhandler.TypeAnnotation], owner))
+		}
+	}
+	return owners, nil
+}
+
+// annotatedOwner returns the owner of the type named namespacedName by the
+// owner annotations. The annotations name the owner, rather than identify it
+// by UID.
+func annotatedOwner(ownerType, namespacedName string) string {
+	return ownerType + "/" + namespacedName
+}
+
+// removeUnusedWatches removes the informers of the resources no controller
+// watches anymore, the dependent watches of the resources being unused for
+// gracePeriod, and not looked up in the cache for gracePeriod. The informers
+// shared with the manager are kept. The unused
+// dependent watches are deleted from the watch maps once their informer is
+// removed, along with their event handlers, so that they are not watched
+// twice if watched again.
+func removeUnusedWatches(ctx context.Context, cMap *controllermap.ControllerMap, lookups *cacheLookups,
+	informers informerRemover, gracePeriod time.Duration) {
+	watchesMutex.Lock()
+	defer watchesMutex.Unlock()
+
+	unused := map[schema.GroupVersionKind]bool{}
+	cMap.Range(func(_ schema.GroupVersionKind, contents *controllermap.Contents) {
+		for _, wm := range []*controllermap.WatchMap{contents.OwnerWatchMap, contents.AnnotationWatchMap} {
+			for _, gvk := range wm.Unused(gracePeriod) {
+				unused[gvk] = true
+			}
+		}
+	})
+	for _, gvk := range lookups.unused(gracePeriod) {
+		unused[gvk] = true
+	}
+
+	for gvk := range unused {
+		// the informer is shared by the controllers watching the resource,
+		// and the lookups of the resource in the cache.
+		if cMap.Watching(gvk, gracePeriod) || lookups.used(gvk, gracePeriod) {
+			continue
+		}
+		u := &unstructured.Unstructured{}
+		u.SetGroupVersionKind(gvk)
+		if err := informers.RemoveInformer(ctx, u); err != nil {
+			if errors.Is(err, errSharedInformer) {
+				log.V(1).Info("Keeping the informer of child resource shared with the manager", "kind", gvk)
+			} else {
+				log.Error(err, "Failed to remove the informer of child resource", "kind", gvk)
+			}
+			continue
+		}
+		log.Info("Removed the informer of unused child resource", "kind", gvk)
+		lookups.forget(gvk, gracePeriod)
+		cMap.Range(func(ownerGVK schema.GroupVersionKind, contents *controllermap.Contents) {
+			for watchType, wm := range map[string]*controllermap.WatchMap{
+				"owner":      contents.OwnerWatchMap,
+				"annotation": contents.AnnotationWatchMap,
+			} {
+				if _, ok := wm.Get(gvk); !ok {
+					continue
+				}
+				log.Info("Deleting unused watch of child resource", "kind", gvk, "enqueue_kind", ownerGVK)
+				wm.Delete(gvk)
+				metrics.DependentWatches(ownerGVK.String(), watchType, wm.Len())
+			}
+		})
+	}
+}
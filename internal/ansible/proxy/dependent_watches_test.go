@@ -0,0 +1,194 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/cache/informertest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/controllermap"
+)
+
+type fakeInformers struct {
+	client.Reader
+	removed []schema.GroupVersionKind
+}
+
+func (f *fakeInformers) RemoveInformer(_ context.Context, obj client.Object) error {
+	f.removed = append(f.removed, obj.GetObjectKind().GroupVersionKind())
+	return nil
+}
+
+var _ = Describe("removeUnusedWatches", func() {
+	var (
+		memcached = schema.GroupVersionKind{Group: "cache.example.com", Version: "v1alpha1", Kind: "Memcached"}
+		redis     = schema.GroupVersionKind{Group: "cache.example.com", Version: "v1alpha1", Kind: "Redis"}
+		configMap = schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+		job       = schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"}
+		owner     = types.NamespacedName{Namespace: "default", Name: "example"}
+	)
+
+	It("Should remove the informers of the watches no controller uses", func() {
+		cMap := controllermap.NewControllerMap()
+		memcachedContents := &controllermap.Contents{OwnerWatchMap: controllermap.NewWatchMap(),
+			AnnotationWatchMap: controllermap.NewWatchMap()}
+		redisContents := &controllermap.Contents{OwnerWatchMap: controllermap.NewWatchMap(),
+			AnnotationWatchMap: controllermap.NewWatchMap()}
+		cMap.Store(memcached, memcachedContents, nil)
+		cMap.Store(redis, redisContents, nil)
+
+		// config maps stay watched for redis, jobs are no longer watched,
+		// and the redis resources remain the primary resource of their
+		// controller.
+		for _, gvk := range []schema.GroupVersionKind{configMap, job, redis} {
+			memcachedContents.OwnerWatchMap.Store(gvk)
+			memcachedContents.OwnerWatchMap.Reference(gvk, owner, "1234")
+		}
+		redisContents.OwnerWatchMap.Store(configMap)
+		redisContents.OwnerWatchMap.Reference(configMap, owner, "1234")
+		cMap.Release(memcached, owner)
+
+		informers := &fakeInformers{}
+		removeUnusedWatches(context.TODO(), cMap, nil, informers, time.Hour)
+		Expect(informers.removed).To(BeEmpty())
+		Expect(memcachedContents.OwnerWatchMap.Len()).To(Equal(3))
+
+		removeUnusedWatches(context.TODO(), cMap, nil, informers, 0)
+		Expect(informers.removed).To(Equal([]schema.GroupVersionKind{job}))
+		// the watches of the resources still watched keep their event
+		// handlers, and are kept to not be watched twice.
+		Expect(memcachedContents.OwnerWatchMap.Len()).To(Equal(2))
+		Expect(redisContents.OwnerWatchMap.Len()).To(Equal(1))
+	})
+
+	It("Should remove the informers of the resources no longer looked up", func() {
+		cMap := controllermap.NewControllerMap()
+		memcachedContents := &controllermap.Contents{OwnerWatchMap: controllermap.NewWatchMap(),
+			AnnotationWatchMap: controllermap.NewWatchMap()}
+		cMap.Store(memcached, memcachedContents, nil)
+		memcachedContents.OwnerWatchMap.Store(configMap)
+		memcachedContents.OwnerWatchMap.Reference(configMap, owner, "1234")
+
+		// config maps stay watched, and jobs are looked up while their
+		// dependent watch is no longer used.
+		lookups := newCacheLookups()
+		for _, gvk := range []schema.GroupVersionKind{configMap, job, memcached} {
+			lookups.touch(gvk)
+		}
+		memcachedContents.AnnotationWatchMap.Store(job)
+
+		informers := &fakeInformers{}
+		removeUnusedWatches(context.TODO(), cMap, lookups, informers, time.Hour)
+		Expect(informers.removed).To(BeEmpty())
+		Expect(memcachedContents.AnnotationWatchMap.Len()).To(Equal(1))
+
+		removeUnusedWatches(context.TODO(), cMap, lookups, informers, 0)
+		Expect(informers.removed).To(Equal([]schema.GroupVersionKind{job}))
+		Expect(memcachedContents.AnnotationWatchMap.Len()).To(Equal(0))
+		Expect(lookups.used(job, time.Hour)).To(BeFalse())
+	})
+
+	It("Should release the watches of the owners no longer owning any dependent", func() {
+		cMap := controllermap.NewControllerMap()
+		contents := &controllermap.Contents{OwnerWatchMap: controllermap.NewWatchMap(),
+			AnnotationWatchMap: controllermap.NewWatchMap()}
+		cMap.Store(memcached, contents, nil)
+		other := types.NamespacedName{Namespace: "default", Name: "other"}
+		uids := map[types.NamespacedName]types.UID{owner: "1234", other: "5678"}
+		contents.OwnerWatchMap.Store(configMap)
+		contents.AnnotationWatchMap.Store(job)
+		for _, o := range []types.NamespacedName{owner, other} {
+			contents.OwnerWatchMap.Reference(configMap, o, uids[o])
+			contents.AnnotationWatchMap.Reference(job, o, uids[o])
+		}
+
+		// the owner still owns a config map and a job, the other owner
+		// nothing but a config map owned by a previous owner of the same
+		// name.
+		ownedConfigMap := &unstructured.Unstructured{}
+		ownedConfigMap.SetGroupVersionKind(configMap)
+		ownedConfigMap.SetNamespace("default")
+		ownedConfigMap.SetName("owned")
+		ownedConfigMap.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: "cache.example.com/v1alpha1",
+			Kind: "Memcached", Name: owner.Name, UID: "1234"}})
+		otherConfigMap := &unstructured.Unstructured{}
+		otherConfigMap.SetGroupVersionKind(configMap)
+		otherConfigMap.SetNamespace("default")
+		otherConfigMap.SetName("other")
+		otherConfigMap.SetOwnerReferences([]metav1.OwnerReference{{APIVersion: "cache.example.com/v1alpha1",
+			Kind: "Memcached", Name: other.Name, UID: "0000"}})
+		ownedJob := &unstructured.Unstructured{}
+		ownedJob.SetGroupVersionKind(job)
+		ownedJob.SetNamespace("elsewhere")
+		ownedJob.SetName("owned")
+		ownedJob.SetAnnotations(map[string]string{
+			"operator-sdk/primary-resource":      "default/example",
+			"operator-sdk/primary-resource-type": "Memcached.cache.example.com",
+		})
+		dependents := fakeclient.NewClientBuilder().WithObjects(ownedConfigMap, otherConfigMap, ownedJob).Build()
+
+		// the owners referencing the watches within the grace period are
+		// kept, their dependents possibly not in the cache yet.
+		releaseUnowned(context.TODO(), cMap, dependents, time.Hour)
+		Expect(contents.OwnerWatchMap.Owners(configMap, time.Now())).To(Equal(uids))
+		Expect(contents.AnnotationWatchMap.Owners(job, time.Now())).To(Equal(uids))
+
+		releaseUnowned(context.TODO(), cMap, dependents, 0)
+		owned := map[types.NamespacedName]types.UID{owner: "1234"}
+		Expect(contents.OwnerWatchMap.Owners(configMap, time.Now())).To(Equal(owned))
+		Expect(contents.AnnotationWatchMap.Owners(job, time.Now())).To(Equal(owned))
+	})
+
+	It("Should keep the informers shared with the manager", func() {
+		secret := schema.GroupVersionKind{Version: "v1", Kind: "Secret"}
+		shared := NewSharedCache(&informertest.FakeInformers{}, scheme.Scheme)
+		// the secrets are the primary resource of a controller, the config
+		// maps are listed by the client of the manager, and the jobs are
+		// watched by the proxy alone.
+		_, err := shared.GetInformer(context.TODO(), &corev1.Secret{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(shared.List(context.TODO(), &corev1.ConfigMapList{})).To(Succeed())
+		_, err = shared.Unwrap().GetInformer(context.TODO(), &batchv1.Job{})
+		Expect(err).NotTo(HaveOccurred())
+
+		cMap := controllermap.NewControllerMap()
+		contents := &controllermap.Contents{OwnerWatchMap: controllermap.NewWatchMap(),
+			AnnotationWatchMap: controllermap.NewWatchMap()}
+		cMap.Store(memcached, contents, nil)
+		for _, gvk := range []schema.GroupVersionKind{secret, configMap, job} {
+			contents.OwnerWatchMap.Store(gvk)
+		}
+
+		removeUnusedWatches(context.TODO(), cMap, nil, shared, 0)
+		informers := shared.Unwrap().(*informertest.FakeInformers).InformersByGVK
+		Expect(informers).To(HaveKey(secret))
+		Expect(informers).NotTo(HaveKey(job))
+		Expect(contents.OwnerWatchMap.Keys()).To(ConsistOf(secret, configMap))
+	})
+})
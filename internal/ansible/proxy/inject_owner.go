@@ -19,8 +19,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
 	"net/http/httputil"
+	"strings"
 
 	"github.com/operator-framework/operator-lib/handler"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -28,17 +30,20 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/set"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/yaml"
 
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/metrics"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/controllermap"
 	k8sRequest "github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/requestfactory"
 	"github.com/operator-framework/ansible-operator-plugins/internal/util/k8sutil"
 )
 
-// injectOwnerReferenceHandler will handle proxied requests and inject the
-// owner reference found in the authorization header. The Authorization is
-// then deleted so that the proxy can re-set with the correct authorization.
+// injectOwnerReferenceHandler will handle proxied requests creating, replacing
+// or applying resources, and inject the owner reference of the run found with
+// its token.
 type injectOwnerReferenceHandler struct {
 	next              http.Handler
 	cMap              *controllermap.ControllerMap
@@ -50,8 +55,8 @@ type injectOwnerReferenceHandler struct {
 }
 
 func (i *injectOwnerReferenceHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	switch req.Method {
-	case http.MethodPost:
+	switch {
+	case injectsOwner(req):
 		dump, _ := httputil.DumpRequest(req, false)
 		log.V(2).Info("Dumping request", "RequestDump", string(dump))
 		rf := k8sRequest.RequestInfoFactory{APIPrefixes: set.New("api", "apis"),
@@ -116,8 +121,7 @@ func (i *injectOwnerReferenceHandler) ServeHTTP(w http.ResponseWriter, req *http
 				http.Error(w, m, http.StatusInternalServerError)
 				return
 			}
-			data := &unstructured.Unstructured{}
-			err = json.Unmarshal(body, data)
+			data, err := decodeObject(req.Header.Get("Content-Type"), body)
 			if err != nil {
 				m := "Could not deserialize request body"
 				log.Error(err, m)
@@ -136,6 +140,17 @@ func (i *injectOwnerReferenceHandler) ServeHTTP(w http.ResponseWriter, req *http
 				Version: ownerGV.Version,
 				Kind:    owner.Kind,
 			}
+			name := r.Name
+			if name == "" {
+				name = data.GetName()
+			}
+			if isOwner(ownerGVK, owner.Namespace, owner.Name, k, r.Namespace, name) {
+				// A run replacing or applying its own CR must not make it
+				// its own owner.
+				log.V(2).Info("Not injecting the owner reference of the owner itself", "gvk", k)
+				req.Body = io.NopCloser(bytes.NewBuffer(body))
+				break
+			}
 			ownerObject := &unstructured.Unstructured{}
 			ownerObject.SetGroupVersionKind(ownerGVK)
 			ownerObject.SetNamespace(owner.Namespace)
@@ -148,7 +163,8 @@ func (i *injectOwnerReferenceHandler) ServeHTTP(w http.ResponseWriter, req *http
 				return
 			}
 			if addOwnerRef {
-				data.SetOwnerReferences(append(data.GetOwnerReferences(), owner.OwnerReference))
+				setOwnerReference(data, owner.OwnerReference)
+				metrics.OwnerReferenceInjected(ownerGVK.String())
 			} else {
 				err := handler.SetOwnerAnnotations(ownerObject, data)
 				if err != nil {
@@ -157,6 +173,7 @@ func (i *injectOwnerReferenceHandler) ServeHTTP(w http.ResponseWriter, req *http
 					http.Error(w, m, http.StatusBadRequest)
 					return
 				}
+				metrics.OwnerAnnotationsInjected(ownerGVK.String())
 			}
 			newBody, err := json.Marshal(data.Object)
 			if err != nil {
@@ -165,7 +182,7 @@ func (i *injectOwnerReferenceHandler) ServeHTTP(w http.ResponseWriter, req *http
 				http.Error(w, m, http.StatusInternalServerError)
 				return
 			}
-			log.V(2).Info("Serialized body", "Body", string(newBody))
+			log.V(2).Info("Serialized body", "Body", redactBody(i.cMap, newBody))
 			req.Body = io.NopCloser(bytes.NewBuffer(newBody))
 			req.ContentLength = int64(len(newBody))
 
@@ -190,3 +207,62 @@ func (i *injectOwnerReferenceHandler) ServeHTTP(w http.ResponseWriter, req *http
 	}
 	i.next.ServeHTTP(w, req)
 }
+
+// injectsOwner - whether the request creates or replaces a resource, whose
+// body is given an owner: a POST, a PUT or a server-side apply PATCH.
+func injectsOwner(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodPost, http.MethodPut:
+		return true
+	case http.MethodPatch:
+		mediaType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+		return err == nil && mediaType == string(types.ApplyPatchType)
+	}
+	return false
+}
+
+// decodeObject - decodes the body of a request, in YAML if its content type
+// is YAML, as for server-side apply, or in JSON otherwise. The owner is
+// injected in the object, which is then encoded in JSON, also valid YAML.
+func decodeObject(contentType string, body []byte) (*unstructured.Unstructured, error) {
+	if isYAML(contentType) {
+		var err error
+		if body, err = yaml.YAMLToJSON(body); err != nil {
+			return nil, err
+		}
+	}
+	data := &unstructured.Unstructured{}
+	if err := json.Unmarshal(body, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// isYAML - whether the media type of contentType is application/yaml or has
+// the +yaml suffix.
+func isYAML(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/yaml" || strings.HasSuffix(mediaType, "+yaml")
+}
+
+// isOwner - whether the object of kind k named name in namespace is the owner
+// of kind ownerGVK named ownerName in ownerNamespace, whatever the version.
+func isOwner(ownerGVK schema.GroupVersionKind, ownerNamespace, ownerName string, k schema.GroupVersionKind,
+	namespace, name string) bool {
+	return ownerGVK.GroupKind() == k.GroupKind() && ownerNamespace == namespace && ownerName == name
+}
+
+// setOwnerReference - adds ownerRef to the owner references of data, unless
+// already there, as when replacing or applying a resource created before.
+func setOwnerReference(data *unstructured.Unstructured, ownerRef metav1.OwnerReference) {
+	ownerRefs := data.GetOwnerReferences()
+	for _, ref := range ownerRefs {
+		if ref.UID == ownerRef.UID {
+			return
+		}
+	}
+	data.SetOwnerReferences(append(ownerRefs, ownerRef))
+}
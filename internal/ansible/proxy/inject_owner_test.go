@@ -28,7 +28,9 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/credentials"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/kubeconfig"
 )
 
@@ -72,17 +74,23 @@ var _ = Describe("injectOwnerReferenceHandler", func() {
 				Fail(fmt.Sprintf("Failed to create http request: %v", err))
 			}
 
-			username, err := kubeconfig.EncodeOwnerRef(
-				metav1.OwnerReference{
-					APIVersion: "v1",
-					Kind:       "Pod",
-					Name:       po.GetName(),
-					UID:        po.GetUID(),
-				}, "default")
+			token, err := testCredentials.Issue(credentials.Credential{
+				Owner: kubeconfig.NamespacedOwnerReference{
+					OwnerReference: metav1.OwnerReference{
+						APIVersion: "v1",
+						Kind:       "Pod",
+						Name:       po.GetName(),
+						UID:        po.GetUID(),
+					},
+					Namespace: "default",
+				},
+				Ident: "test-injection",
+			})
 			if err != nil {
-				Fail("Failed to encode owner reference")
+				Fail("Failed to issue a token")
 			}
-			req.SetBasicAuth(username, "unused")
+			defer testCredentials.Revoke(token)
+			req.Header.Set("Authorization", "Bearer "+token)
 
 			httpClient := http.Client{}
 
@@ -91,6 +99,7 @@ var _ = Describe("injectOwnerReferenceHandler", func() {
 				if err != nil {
 					Fail(fmt.Sprintf("Failed to delete configmap: %v", err))
 				}
+				cleanupReq.Header.Set("Authorization", "Bearer "+token)
 				_, err = httpClient.Do(cleanupReq)
 				if err != nil {
 					Fail(fmt.Sprintf("Failed to delete configmap: %v", err))
@@ -123,3 +132,78 @@ var _ = Describe("injectOwnerReferenceHandler", func() {
 		})
 	})
 })
+
+var _ = Describe("injectsOwner", func() {
+	DescribeTable("Should tell the requests whose body is given an owner",
+		func(method, contentType string, expected bool) {
+			req, err := http.NewRequest(method, "http://localhost:8888/api/v1/namespaces/default/configmaps/test",
+				nil)
+			Expect(err).NotTo(HaveOccurred())
+			if contentType != "" {
+				req.Header.Set("Content-Type", contentType)
+			}
+			Expect(injectsOwner(req)).To(Equal(expected))
+		},
+		Entry("create", http.MethodPost, "application/json", true),
+		Entry("replace", http.MethodPut, "application/yaml", true),
+		Entry("server-side apply", http.MethodPatch, "application/apply-patch+yaml", true),
+		Entry("server-side apply with parameters", http.MethodPatch, "application/apply-patch+yaml; charset=utf-8", true),
+		Entry("merge patch", http.MethodPatch, "application/merge-patch+json", false),
+		Entry("strategic merge patch", http.MethodPatch, "application/strategic-merge-patch+json", false),
+		Entry("get", http.MethodGet, "", false),
+	)
+})
+
+var _ = Describe("decodeObject", func() {
+	DescribeTable("Should decode the bodies of their content type",
+		func(contentType, body string) {
+			data, err := decodeObject(contentType, []byte(body))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(data.GetKind()).To(Equal("ConfigMap"))
+			Expect(data.GetName()).To(Equal("test"))
+			Expect(data.Object["data"]).To(Equal(map[string]interface{}{"replicas": "3"}))
+		},
+		Entry("JSON", "application/json",
+			`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "test"}, "data": {"replicas": "3"}}`),
+		Entry("YAML", "application/yaml",
+			"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\ndata:\n  replicas: \"3\"\n"),
+		Entry("server-side apply", "application/apply-patch+yaml; charset=utf-8",
+			"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\ndata:\n  replicas: \"3\"\n"),
+	)
+
+	It("Should not decode YAML as JSON", func() {
+		_, err := decodeObject("application/json", []byte("apiVersion: v1\nkind: ConfigMap\n"))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("Should fail without a kind", func() {
+		_, err := decodeObject("application/yaml", []byte("metadata:\n  name: test\n"))
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+var _ = Describe("isOwner", func() {
+	ownerGVK := schema.GroupVersionKind{Group: "cache.example.com", Version: "v1", Kind: "Memcached"}
+	DescribeTable("Should tell the owner itself",
+		func(k schema.GroupVersionKind, namespace, name string, expected bool) {
+			Expect(isOwner(ownerGVK, "default", "example", k, namespace, name)).To(Equal(expected))
+		},
+		Entry("owner", ownerGVK, "default", "example", true),
+		Entry("owner in another version", ownerGVK.GroupKind().WithVersion("v1beta1"), "default", "example", true),
+		Entry("other kind", schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, "default", "example", false),
+		Entry("other namespace", ownerGVK, "other", "example", false),
+		Entry("other name", ownerGVK, "default", "other", false),
+	)
+})
+
+var _ = Describe("setOwnerReference", func() {
+	It("Should add the owner reference once", func() {
+		ownerRef := metav1.OwnerReference{APIVersion: "v1", Kind: "Pod", Name: "owner", UID: "1234"}
+		data, err := decodeObject("application/json",
+			[]byte(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "test"}}`))
+		Expect(err).NotTo(HaveOccurred())
+		setOwnerReference(data, ownerRef)
+		setOwnerReference(data, ownerRef)
+		Expect(data.GetOwnerReferences()).To(Equal([]metav1.OwnerReference{ownerRef}))
+	})
+})
@@ -0,0 +1,96 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/felixge/httpsnoop"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/utils/set"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/credentials"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/inventory"
+	k8sRequest "github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/requestfactory"
+)
+
+// recordInventory - records the objects the runs successfully create or
+// update through h to the inventory of their run. Dry runs, as in check
+// mode, are not recorded.
+func recordInventory(h http.Handler, recorder *inventory.Recorder, restMapper meta.RESTMapper) http.Handler {
+	rf := k8sRequest.RequestInfoFactory{APIPrefixes: set.New("api", "apis"),
+		GrouplessAPIPrefixes: set.New("api")}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c, ok := credentials.FromContext(req.Context())
+		if !ok || c.Ident == "" || restMapper == nil || req.URL.Query().Has("dryRun") {
+			h.ServeHTTP(w, req)
+			return
+		}
+		switch req.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch:
+		default:
+			h.ServeHTTP(w, req)
+			return
+		}
+		r, err := rf.NewRequestInfo(req)
+		if err != nil || !r.IsResourceRequest || r.Subresource != "" {
+			h.ServeHTTP(w, req)
+			return
+		}
+
+		// the name of a created object is in its body, or, if generated, in
+		// the object returned in the response.
+		name := r.Name
+		if name == "" && req.Method == http.MethodPost && req.Body != nil {
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				log.Error(err, "Could not read request body")
+			}
+			req.Body = io.NopCloser(bytes.NewBuffer(body))
+			if data, err := decodeObject(req.Header.Get("Content-Type"), body); err == nil {
+				name = data.GetName()
+			}
+		}
+
+		var code int
+		if name == "" && req.Method == http.MethodPost {
+			var body []byte
+			code, body = serveCapturingResponse(h, w, req)
+			if obj, err := decodeWrittenObject(w.Header(), body); err == nil {
+				name = obj.GetName()
+			} else {
+				log.V(1).Info("Could not decode the created object", "path", req.URL.Path, "error", err.Error())
+			}
+		} else {
+			code = httpsnoop.CaptureMetrics(h, w, req).Code
+		}
+		if code < http.StatusOK || code >= http.StatusMultipleChoices || name == "" {
+			return
+		}
+		gvk, err := getGVKFromRequestInfo(r, restMapper)
+		if err != nil {
+			log.Error(err, "Could not get the kind of the object to record", "path", req.URL.Path)
+			return
+		}
+		recorder.Record(c.Ident, inventory.Object{
+			APIVersion: gvk.GroupVersion().String(),
+			Kind:       gvk.Kind,
+			Namespace:  r.Namespace,
+			Name:       name,
+		})
+	})
+}
@@ -0,0 +1,151 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package inventory records the objects each run creates or updates through
+// the proxy, so that the objects a run no longer produces can be pruned.
+package inventory
+
+import (
+	"sort"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Object - an object created or updated by a run.
+type Object struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// GroupVersionKind - the GVK of the object.
+func (o Object) GroupVersionKind() schema.GroupVersionKind {
+	return schema.FromAPIVersionAndKind(o.APIVersion, o.Kind)
+}
+
+// key identifies an object regardless of the version it was written with.
+type key struct {
+	schema.GroupKind
+	Namespace string
+	Name      string
+}
+
+func (o Object) key() key {
+	return key{GroupKind: o.GroupVersionKind().GroupKind(), Namespace: o.Namespace, Name: o.Name}
+}
+
+// Recorder - records the objects of the runs it was started for. A nil
+// Recorder records nothing.
+type Recorder struct {
+	mutex sync.Mutex
+	runs  map[string]map[key]Object
+}
+
+// NewRecorder - creates a recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{runs: map[string]map[key]Object{}}
+}
+
+// Start - starts recording the objects of the run of ident.
+func (r *Recorder) Start(ident string) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.runs[ident] = map[key]Object{}
+}
+
+// Record - records an object created or updated by the run of ident, if
+// started.
+func (r *Recorder) Record(ident string, o Object) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if objects, ok := r.runs[ident]; ok {
+		objects[o.key()] = o
+	}
+}
+
+// Stop - stops recording the objects of the run of ident, and returns them.
+func (r *Recorder) Stop(ident string) []Object {
+	if r == nil {
+		return nil
+	}
+	r.mutex.Lock()
+	objects := r.runs[ident]
+	delete(r.runs, ident)
+	r.mutex.Unlock()
+
+	list := make([]Object, 0, len(objects))
+	for _, o := range objects {
+		list = append(list, o)
+	}
+	Sort(list)
+	return list
+}
+
+// Sort - sorts objects by kind, namespace and name.
+func Sort(objects []Object) {
+	sort.Slice(objects, func(i, j int) bool {
+		a, b := objects[i].key(), objects[j].key()
+		if a.Group != b.Group {
+			return a.Group < b.Group
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+}
+
+// Difference - the objects of a that are not in b, regardless of the version
+// they were written with.
+func Difference(a, b []Object) []Object {
+	inB := make(map[key]bool, len(b))
+	for _, o := range b {
+		inB[o.key()] = true
+	}
+	var diff []Object
+	for _, o := range a {
+		if !inB[o.key()] {
+			diff = append(diff, o)
+		}
+	}
+	return diff
+}
+
+// Union - the objects of a and b, those of b taking precedence.
+func Union(a, b []Object) []Object {
+	objects := map[key]Object{}
+	for _, o := range a {
+		objects[o.key()] = o
+	}
+	for _, o := range b {
+		objects[o.key()] = o
+	}
+	union := make([]Object, 0, len(objects))
+	for _, o := range objects {
+		union = append(union, o)
+	}
+	Sort(union)
+	return union
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package inventory
+
+import (
+	"reflect"
+	"testing"
+)
+
+var (
+	configMap  = Object{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: "example"}
+	deployment = Object{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "default", Name: "example"}
+	secret     = Object{APIVersion: "v1", Kind: "Secret", Namespace: "default", Name: "example"}
+)
+
+func TestRecorder(t *testing.T) {
+	r := NewRecorder()
+	// not started.
+	r.Record("1", configMap)
+	if objects := r.Stop("1"); len(objects) != 0 {
+		t.Fatalf("Unexpected objects %v recorded before the run started", objects)
+	}
+
+	r.Start("1")
+	r.Start("2")
+	r.Record("1", secret)
+	r.Record("1", configMap)
+	r.Record("1", configMap)
+	r.Record("2", deployment)
+	if objects := r.Stop("1"); !reflect.DeepEqual(objects, []Object{configMap, secret}) {
+		t.Fatalf("Unexpected objects %v", objects)
+	}
+	if objects := r.Stop("1"); len(objects) != 0 {
+		t.Fatalf("Unexpected objects %v after the run stopped", objects)
+	}
+	if objects := r.Stop("2"); !reflect.DeepEqual(objects, []Object{deployment}) {
+		t.Fatalf("Unexpected objects %v", objects)
+	}
+}
+
+func TestNilRecorder(t *testing.T) {
+	var r *Recorder
+	r.Start("1")
+	r.Record("1", configMap)
+	if objects := r.Stop("1"); objects != nil {
+		t.Fatalf("Unexpected objects %v", objects)
+	}
+}
+
+func TestDifferenceAndUnion(t *testing.T) {
+	// the same deployment, written with an older version.
+	betaDeployment := deployment
+	betaDeployment.APIVersion = "apps/v1beta1"
+
+	testCases := []struct {
+		name       string
+		a, b       []Object
+		difference []Object
+		union      []Object
+	}{
+		{
+			name:  "empty",
+			union: []Object{},
+		},
+		{
+			name:       "disjoint",
+			a:          []Object{secret},
+			b:          []Object{configMap},
+			difference: []Object{secret},
+			union:      []Object{configMap, secret},
+		},
+		{
+			name:       "overlapping",
+			a:          []Object{configMap, secret},
+			b:          []Object{configMap},
+			difference: []Object{secret},
+			union:      []Object{configMap, secret},
+		},
+		{
+			name:  "other version",
+			a:     []Object{betaDeployment},
+			b:     []Object{deployment},
+			union: []Object{deployment},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if difference := Difference(tc.a, tc.b); !reflect.DeepEqual(difference, tc.difference) {
+				t.Fatalf("Unexpected difference %v, expected %v", difference, tc.difference)
+			}
+			if union := Union(tc.a, tc.b); !reflect.DeepEqual(union, tc.union) {
+				t.Fatalf("Unexpected union %v, expected %v", union, tc.union)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/credentials"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/inventory"
+)
+
+var _ = Describe("recordInventory", func() {
+	run := credentials.Credential{Ident: "1234"}
+	restMapper := meta.NewDefaultRESTMapper(nil)
+	restMapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+	configMap := func(name string) inventory.Object {
+		return inventory.Object{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: name}
+	}
+
+	DescribeTable("recordInventory",
+		func(method, path, body string, code int, expected []inventory.Object) {
+			recorder := inventory.NewRecorder()
+			recorder.Start(run.Ident)
+			apiServer := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(code)
+				_, _ = w.Write([]byte(`{"kind":"ConfigMap","metadata":{"name":"example-x7k2","resourceVersion":"42"}}`))
+			})
+			req := httptest.NewRequest(method, path, strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req = req.WithContext(credentials.WithCredential(req.Context(), run))
+			recordInventory(apiServer, recorder, restMapper).ServeHTTP(httptest.NewRecorder(), req)
+			Expect(recorder.Stop(run.Ident)).To(Equal(expected))
+		},
+		Entry("create", http.MethodPost, "/api/v1/namespaces/default/configmaps",
+			`{"kind":"ConfigMap","metadata":{"name":"example"}}`, http.StatusCreated, []inventory.Object{configMap("example")}),
+		Entry("create with a generated name", http.MethodPost, "/api/v1/namespaces/default/configmaps",
+			`{"kind":"ConfigMap","metadata":{"generateName":"example-"}}`, http.StatusCreated,
+			[]inventory.Object{configMap("example-x7k2")}),
+		Entry("update", http.MethodPut, "/api/v1/namespaces/default/configmaps/example", `{}`, http.StatusOK,
+			[]inventory.Object{configMap("example")}),
+		Entry("failed create", http.MethodPost, "/api/v1/namespaces/default/configmaps",
+			`{"kind":"ConfigMap","metadata":{"generateName":"example-"}}`, http.StatusConflict, []inventory.Object{}),
+	)
+})
@@ -16,8 +16,6 @@ package kubeconfig
 
 import (
 	"bytes"
-	"encoding/base64"
-	"encoding/json"
 	"errors"
 	"html/template"
 	"net/url"
@@ -29,10 +27,8 @@ import (
 
 var log = logf.Log.WithName("kubeconfig")
 
-// kubectl, as of 1.10.5, only does basic auth if the username is present in
-// the URL. The python client used by ansible, as of 6.0.0, only does basic
-// auth if the username and password are provided under the "user" key within
-// "users".
+// The run authenticates to the proxy with the bearer token issued for it,
+// which the proxy maps to the owner of the run.
 const kubeConfigTemplate = `---
 apiVersion: v1
 kind: Config
@@ -51,13 +47,12 @@ preferences: {}
 users:
 - name: admin/proxy-server
   user:
-    username: {{.Username}}
-    password: unused
+    token: {{.Token}}
 `
 
 // values holds the data used to render the template
 type values struct {
-	Username  string
+	Token     string
 	ProxyURL  string
 	Namespace string
 }
@@ -67,31 +62,15 @@ type NamespacedOwnerReference struct {
 	Namespace string
 }
 
-// EncodeOwnerRef takes an ownerReference and a namespace and returns a base64 encoded
-// string that can be used in the username field of a request to associate the
-// owner with the request being made.
-func EncodeOwnerRef(ownerRef metav1.OwnerReference, namespace string) (string, error) {
-	nsOwnerRef := NamespacedOwnerReference{OwnerReference: ownerRef, Namespace: namespace}
-	ownerRefJSON, err := json.Marshal(nsOwnerRef)
-	if err != nil {
-		return "", err
-	}
-	return base64.URLEncoding.EncodeToString(ownerRefJSON), nil
-}
-
-// Create renders a kubeconfig template and writes it to disk
-func Create(ownerRef metav1.OwnerReference, proxyURL string, namespace string) (*os.File, error) {
+// Create renders a kubeconfig template and writes it to disk. The requests
+// made with it authenticate to the proxy with token.
+func Create(proxyURL string, namespace string, token string) (*os.File, error) {
 	parsedURL, err := url.Parse(proxyURL)
 	if err != nil {
 		return nil, err
 	}
-	username, err := EncodeOwnerRef(ownerRef, namespace)
-	if err != nil {
-		return nil, err
-	}
-	parsedURL.User = url.User(username)
 	v := values{
-		Username:  username,
+		Token:     token,
 		ProxyURL:  parsedURL.String(),
 		Namespace: namespace,
 	}
@@ -0,0 +1,95 @@
+# Copyright 2026 The Operator-SDK Authors
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# Routes the connections to the ansible-operator proxy through its unix
+# socket. The ansible-operator puts this module on the PYTHONPATH of the runs
+# when the proxy serves on a unix socket, so that the kubernetes client of the
+# kubernetes.core modules reaches the proxy at http://ansible-operator-proxy,
+# the server of the kubeconfig of the runs. Only the clients built on urllib3
+# are routed: the others, e.g. kubectl or the ansible.builtin.uri module, still
+# need the proxy to serve on a TCP port. The python processes of a run exit
+# with an error if the installed urllib3 cannot be routed.
+#
+# The sitecustomize module this one shadows on the PYTHONPATH, if any, is
+# loaded afterwards.
+
+import importlib.machinery
+import importlib.util
+import os
+import socket
+import sys
+
+_PROXY_HOST = "ansible-operator-proxy"
+_socket_path = os.environ.get("ANSIBLE_OPERATOR_PROXY_SOCKET")
+
+
+# The major versions of urllib3 whose HTTPConnection._new_conn returns the
+# socket of a connection, which is what is replaced to route it.
+_SUPPORTED_URLLIB3 = ("1", "2")
+
+
+def _fail(msg):
+    # an exception would only be reported as an error in sitecustomize, and
+    # the run would go on without reaching the proxy.
+    sys.stderr.write("ansible-operator: %s; serve the proxy on a TCP port instead\n" % msg)
+    sys.stderr.flush()
+    os._exit(1)
+
+
+def _route_to_socket():
+    try:
+        import urllib3
+        from urllib3 import connection
+    except ImportError:
+        # the kubernetes client is built on urllib3, so no client to route.
+        return
+
+    version = getattr(urllib3, "__version__", "unknown")
+    if version.split(".")[0] not in _SUPPORTED_URLLIB3:
+        _fail("urllib3 %s is not supported by the proxy socket transport" % version)
+    new_conn = getattr(connection.HTTPConnection, "_new_conn", None)
+    if new_conn is None:
+        _fail("urllib3 %s has no HTTPConnection._new_conn to route to the proxy socket" % version)
+
+    def _new_conn(self):
+        if self.host != _PROXY_HOST:
+            return new_conn(self)
+        sock = socket.socket(socket.AF_UNIX, socket.SOCK_STREAM)
+        # the timeout may be a sentinel standing for the default one.
+        if isinstance(self.timeout, (int, float)):
+            sock.settimeout(self.timeout)
+        try:
+            sock.connect(_socket_path)
+        except OSError:
+            sock.close()
+            raise
+        return sock
+
+    connection.HTTPConnection._new_conn = _new_conn
+
+
+def _load_shadowed():
+    here = os.path.dirname(os.path.abspath(__file__))
+    path = [p for p in sys.path if os.path.abspath(p or os.curdir) != here]
+    spec = importlib.machinery.PathFinder.find_spec("sitecustomize", path)
+    if spec is None or spec.loader is None:
+        return
+    module = importlib.util.module_from_spec(spec)
+    spec.loader.exec_module(module)
+
+
+if _socket_path:
+    _route_to_socket()
+
+_load_shadowed()
@@ -0,0 +1,71 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfig
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// SocketProxyURL - the proxy URL of the kubeconfig of the runs when the
+	// proxy serves on a unix socket. The connections to its host go through
+	// the socket.
+	SocketProxyURL = "http://ansible-operator-proxy"
+
+	// SocketEnvVar - the environment variable holding the path of the socket
+	// the proxy serves on.
+	SocketEnvVar = "ANSIBLE_OPERATOR_PROXY_SOCKET"
+)
+
+// socketShim routes the connections of the python kubernetes client to the
+// host of SocketProxyURL through the socket. Python loads it on startup,
+// from the PYTHONPATH, as sitecustomize. Only the clients built on urllib3 are
+// routed, the others still need the proxy to serve on a TCP port.
+//
+//go:embed sitecustomize.py
+var socketShim []byte
+
+// SocketEnv - writes to dir the python module routing the connections of the
+// runs to SocketProxyURL through the unix socket at socketPath, and returns
+// the environment variables the runs need to load it. A sitecustomize module
+// already on the PYTHONPATH is loaded by the module, after it.
+func SocketEnv(dir, socketPath string) (map[string]string, error) {
+	socketPath, err := filepath.Abs(socketPath)
+	if err != nil {
+		return nil, err
+	}
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "sitecustomize.py"), socketShim, 0o644); err != nil {
+		return nil, fmt.Errorf("failed to write the python socket shim: %w", err)
+	}
+
+	pythonPath := dir
+	if existing := os.Getenv("PYTHONPATH"); existing != "" {
+		pythonPath += string(os.PathListSeparator) + existing
+	}
+	return map[string]string{
+		"PYTHONPATH": pythonPath,
+		SocketEnvVar: socketPath,
+	}, nil
+}
@@ -0,0 +1,144 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kubeconfig
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func TestSocketEnv(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("PYTHONPATH", "/opt/python")
+
+	env, err := SocketEnv(filepath.Join(dir, "python"), filepath.Join(dir, "proxy.sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	shim, err := os.ReadFile(filepath.Join(dir, "python", "sitecustomize.py"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(shim, socketShim) {
+		t.Fatal("Unexpected content of the python socket shim")
+	}
+	if expected := filepath.Join(dir, "python") + string(os.PathListSeparator) + "/opt/python"; env["PYTHONPATH"] != expected {
+		t.Fatalf("Unexpected PYTHONPATH %q, expected %q", env["PYTHONPATH"], expected)
+	}
+	if expected := filepath.Join(dir, "proxy.sock"); env[SocketEnvVar] != expected {
+		t.Fatalf("Unexpected socket path %q, expected %q", env[SocketEnvVar], expected)
+	}
+}
+
+func TestSocketEnvLoadsShadowedSitecustomize(t *testing.T) {
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("python3 is not available")
+	}
+	dir := t.TempDir()
+	shadowed := filepath.Join(dir, "site")
+	if err := os.MkdirAll(shadowed, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(shadowed, "sitecustomize.py"), []byte("print('shadowed')\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PYTHONPATH", shadowed)
+
+	env, err := SocketEnv(filepath.Join(dir, "python"), filepath.Join(dir, "proxy.sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command(python, "-c", "import sitecustomize; print(sitecustomize.__file__)")
+	cmd.Env = append(os.Environ(), "PYTHONPATH="+env["PYTHONPATH"], SocketEnvVar+"="+env[SocketEnvVar])
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("Failed to run python: %v\n%s", err, out)
+	}
+	expected := "shadowed\n" + filepath.Join(dir, "python", "sitecustomize.py") + "\n"
+	if string(out) != expected {
+		t.Fatalf("Unexpected output %q, expected %q", out, expected)
+	}
+}
+
+// runWithURLLib3 runs python code with the socket shim and a fake urllib3 of
+// the given version on the PYTHONPATH.
+func runWithURLLib3(t *testing.T, version, code string) ([]byte, error) {
+	t.Helper()
+	python, err := exec.LookPath("python3")
+	if err != nil {
+		t.Skip("python3 is not available")
+	}
+	dir := t.TempDir()
+	lib := filepath.Join(dir, "lib", "urllib3")
+	if err := os.MkdirAll(lib, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(lib, "__init__.py"), []byte("__version__ = \""+version+"\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	connection := "class HTTPConnection:\n    def _new_conn(self):\n        return None\n"
+	if err := os.WriteFile(filepath.Join(lib, "connection.py"), []byte(connection), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PYTHONPATH", filepath.Join(dir, "lib"))
+
+	env, err := SocketEnv(filepath.Join(dir, "python"), filepath.Join(dir, "proxy.sock"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command(python, "-c", code)
+	cmd.Env = append(os.Environ(), "PYTHONPATH="+env["PYTHONPATH"], SocketEnvVar+"="+env[SocketEnvVar])
+	return cmd.CombinedOutput()
+}
+
+func TestSocketShimRoutesURLLib3(t *testing.T) {
+	out, err := runWithURLLib3(t, "2.2.1",
+		"from urllib3 import connection; print(connection.HTTPConnection._new_conn.__module__)")
+	if err != nil {
+		t.Fatalf("Failed to run python: %v\n%s", err, out)
+	}
+	if string(out) != "sitecustomize\n" {
+		t.Fatalf("Expected the connections of urllib3 to be routed, got %q", out)
+	}
+}
+
+func TestSocketShimUnsupportedURLLib3(t *testing.T) {
+	out, err := runWithURLLib3(t, "3.0.0", "print('reached')")
+	if err == nil {
+		t.Fatalf("Expected python to fail with an unsupported urllib3, got %q", out)
+	}
+	if !bytes.Contains(out, []byte("urllib3 3.0.0 is not supported")) || bytes.Contains(out, []byte("reached")) {
+		t.Fatalf("Unexpected output %q", out)
+	}
+}
+
+func TestCreateSocketProxyURL(t *testing.T) {
+	kc, err := Create(SocketProxyURL, "default", "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(kc.Name())
+	content, err := os.ReadFile(kc.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(content, []byte("server: "+SocketProxyURL+"\n")) {
+		t.Fatalf("Unexpected kubeconfig:\n%s", content)
+	}
+}
@@ -17,11 +17,10 @@ package proxy
 import (
 	"bytes"
 	"context"
-	"encoding/base64"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"strings"
 	"sync"
@@ -34,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
@@ -41,9 +41,16 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/handler"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/metrics"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/audit"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/controllermap"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/credentials"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/inventory"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/kubeconfig"
 	k8sRequest "github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/requestfactory"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/writes"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/redact"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/tracing"
 )
 
 // This is the default timeout to wait for the cache to respond
@@ -51,8 +58,10 @@ import (
 const cacheEstablishmentTimeout = 6 * time.Second
 const AutoSkipCacheREList = "^/api/.*/pods/.*/exec,^/api/.*/pods/.*/attach"
 
-// RequestLogHandler - log the requests that come through the proxy.
-func RequestLogHandler(h http.Handler) http.Handler {
+// RequestLogHandler - log the requests that come through the proxy. Sensitive
+// values in the body are masked using the redactor of the watch for the body's
+// kind, and Secret data is always masked.
+func RequestLogHandler(h http.Handler, cMap *controllermap.ControllerMap) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		// read body
 		body, err := io.ReadAll(req.Body)
@@ -61,7 +70,7 @@ func RequestLogHandler(h http.Handler) http.Handler {
 		}
 		// fix body
 		req.Body = io.NopCloser(bytes.NewBuffer(body))
-		log.Info("Request Info", "method", req.Method, "uri", req.RequestURI, "body", string(body))
+		log.Info("Request Info", "method", req.Method, "uri", req.RequestURI, "body", redactBody(cMap, body))
 		// Removing the authorization so that the proxy can set the correct authorization.
 		req.Header.Del("Authorization")
 		h.ServeHTTP(w, req)
@@ -88,6 +97,36 @@ type Options struct {
 	DisableCache      bool
 	OwnerInjection    bool
 	LogRequests       bool
+	// SocketPath is the path of a unix socket the proxy serves on instead
+	// of Address and Port, if set. Only the user of the operator may connect
+	// to it. The socket is shared by every run rather than created per run:
+	// the runs all run as the user of the operator, so they could connect to
+	// the socket of any other run anyway, and the requests are attributed to
+	// their run by its token, as on a TCP port.
+	SocketPath string
+	// Credentials are the tokens the runs authenticate with. Requests
+	// without a valid token are rejected.
+	Credentials *credentials.Store
+	// Audit records the requests of the runs, if set, with their bodies if
+	// AuditBodies is set, redacted.
+	Audit       *audit.Sink
+	AuditBodies bool
+	// Inventory records the objects the runs create or update, for the runs
+	// it was started for.
+	Inventory *inventory.Recorder
+	// Writes tracks the objects the runs write, for the runs it was started
+	// for, whose reads wait up to ReadYourWritesTimeout for the cache to
+	// observe the writes before being served by the API server instead.
+	Writes                *writes.Tracker
+	ReadYourWritesTimeout time.Duration
+	// DependentWatchGracePeriod is how long a dependent watch is kept once no
+	// owner owns an object of the resource in the cache, before it is removed
+	// with its informer, if no other controller watches the resource. The
+	// informers started by the lookups in the cache are likewise removed once
+	// not looked up for that long. The informers of a SharedCache used by
+	// the controllers or the client of the manager are kept. The watches are
+	// never removed if 0.
+	DependentWatchGracePeriod time.Duration
 }
 
 // Run will start a proxy server in a go routine that returns on the error
@@ -107,6 +146,9 @@ func Run(done chan error, o Options) error {
 	if o.WatchedNamespaces == nil {
 		return fmt.Errorf("failed to get list of watched namespaces from options")
 	}
+	if o.Credentials == nil {
+		return fmt.Errorf("failed to get credentials from options")
+	}
 
 	// Create apiResources and
 	discoveryClient, err := discovery.NewDiscoveryClientForConfig(o.KubeConfig)
@@ -142,6 +184,20 @@ func Run(done chan error, o Options) error {
 		o.Cache = informerCache
 	}
 
+	// the proxy uses the informers of the cache shared with the manager
+	// unwrapped, so that it may remove those it alone uses.
+	var informers informerRemover = o.Cache
+	if shared, ok := o.Cache.(*SharedCache); ok {
+		o.Cache = shared.Unwrap()
+	}
+
+	var lookups *cacheLookups
+	if o.DependentWatchGracePeriod > 0 && o.Cache != nil {
+		lookups = newCacheLookups()
+		go collectDependentWatches(context.TODO(), o.ControllerMap, lookups, o.Cache, informers,
+			o.DependentWatchGracePeriod)
+	}
+
 	// Remove the authorization header so the proxy can correctly inject the header.
 	server.Handler = removeAuthorizationHeader(server.Handler)
 
@@ -159,7 +215,7 @@ func Run(done chan error, o Options) error {
 		log.Info("Warning: injection of owner references and dependent watches is turned off")
 	}
 	if o.LogRequests {
-		server.Handler = RequestLogHandler(server.Handler)
+		server.Handler = RequestLogHandler(server.Handler, o.ControllerMap)
 	}
 	if !o.DisableCache {
 		autoSkipCacheRegexp, err := MakeRegexpArray(AutoSkipCacheREList)
@@ -176,10 +232,41 @@ func Run(done chan error, o Options) error {
 			injectOwnerRef:    o.OwnerInjection,
 			apiResources:      resources,
 			skipPathRegexp:    autoSkipCacheRegexp,
+			writes:            o.Writes,
+			writeTimeout:      o.ReadYourWritesTimeout,
+			lookups:           lookups,
 		}
 	}
 
-	l, err := server.Listen(o.Address, o.Port)
+	// Trace every request, including those served from the cache, once
+	// authenticated.
+	server.Handler = tracing.Handler(server.Handler)
+	server.Handler = observeRequests(server.Handler)
+	if o.Writes != nil {
+		server.Handler = recordWrites(server.Handler, o.Writes)
+	}
+	if o.Inventory != nil {
+		server.Handler = recordInventory(server.Handler, o.Inventory, o.RESTMapper)
+	}
+	// The requests rejected by authenticate are audited too, without a run.
+	if o.Audit != nil {
+		server.Handler = audit.Identify(server.Handler)
+	}
+	server.Handler = authenticate(server.Handler, o.Credentials)
+	if o.Audit != nil {
+		auditOptions := audit.HandlerOptions{}
+		if o.AuditBodies {
+			auditOptions.RedactBody = func(body []byte) string { return redactBody(o.ControllerMap, body) }
+		}
+		server.Handler = audit.Handler(server.Handler, o.Audit, auditOptions)
+	}
+
+	var l net.Listener
+	if o.SocketPath != "" {
+		l, err = server.ListenUnix(o.SocketPath)
+	} else {
+		l, err = server.Listen(o.Address, o.Port)
+	}
 	if err != nil {
 		return err
 	}
@@ -221,10 +308,14 @@ func addWatchToController(owner kubeconfig.NamespacedOwnerReference, cMap *contr
 	}
 	owMap := contents.OwnerWatchMap
 	awMap := contents.AnnotationWatchMap
+	ownerName := types.NamespacedName{Namespace: owner.Namespace, Name: owner.Name}
 	u := &unstructured.Unstructured{}
 	u.SetGroupVersionKind(ownerMapping.GroupVersionKind)
 
-	// Add a watch to controller
+	// Add a watch to controller, not racing with the removal of the unused
+	// watches
+	watchesMutex.Lock()
+	defer watchesMutex.Unlock()
 	if contents.WatchDependentResources && !contents.Blacklist[resource.GroupVersionKind()] {
 		// Store watch in map
 		// Use EnqueueRequestForOwner unless user has configured watching cluster scoped resources and we have to
@@ -233,10 +324,13 @@ func addWatchToController(owner kubeconfig.NamespacedOwnerReference, cMap *contr
 			_, exists := owMap.Get(resource.GroupVersionKind())
 			// If already watching resource no need to add a new watch
 			if exists {
+				owMap.Reference(resource.GroupVersionKind(), ownerName, owner.UID)
 				return nil
 			}
 
 			owMap.Store(resource.GroupVersionKind())
+			owMap.Reference(resource.GroupVersionKind(), ownerName, owner.UID)
+			metrics.DependentWatches(u.GroupVersionKind().String(), "owner", owMap.Len())
 			log.Info("Watching child resource", "kind", resource.GroupVersionKind(),
 				"enqueue_kind", u.GroupVersionKind())
 			err := contents.Controller.Watch(source.Kind(cache, client.Object(resource),
@@ -252,9 +346,12 @@ func addWatchToController(owner kubeconfig.NamespacedOwnerReference, cMap *contr
 			_, exists := awMap.Get(resource.GroupVersionKind())
 			// If already watching resource no need to add a new watch
 			if exists {
+				awMap.Reference(resource.GroupVersionKind(), ownerName, owner.UID)
 				return nil
 			}
 			awMap.Store(resource.GroupVersionKind())
+			awMap.Reference(resource.GroupVersionKind(), ownerName, owner.UID)
+			metrics.DependentWatches(u.GroupVersionKind().String(), "annotation", awMap.Len())
 			ownerGK := schema.GroupKind{
 				Kind:  owner.Kind,
 				Group: ownerGV.Group,
@@ -276,6 +373,18 @@ func addWatchToController(owner kubeconfig.NamespacedOwnerReference, cMap *contr
 	return nil
 }
 
+// redactBody masks a request body for logging.
+func redactBody(cMap *controllermap.ControllerMap, body []byte) string {
+	var redactor *redact.Redactor
+	u := &unstructured.Unstructured{}
+	if err := u.UnmarshalJSON(body); err == nil && cMap != nil {
+		if contents, ok := cMap.Get(u.GroupVersionKind()); ok {
+			redactor = contents.Redactor
+		}
+	}
+	return redactor.Body(body)
+}
+
 func removeAuthorizationHeader(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		req.Header.Del("Authorization")
@@ -283,29 +392,46 @@ func removeAuthorizationHeader(h http.Handler) http.Handler {
 	})
 }
 
+// authenticate - rejects the requests without a valid token, and passes the
+// credential of the token, and the trace context of its run, to h. The
+// requests are made as the user the credential impersonates, if any.
+func authenticate(h http.Handler, store *credentials.Store) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		token, _ := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+		c, ok := store.Lookup(token)
+		if !ok {
+			log.Info("Rejecting request with an unknown or expired token", "method", req.Method,
+				"uri", req.RequestURI)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		// the run may only be the user it was issued the token for.
+		for name := range req.Header {
+			if strings.HasPrefix(name, "Impersonate-") {
+				req.Header.Del(name)
+			}
+		}
+		if c.Impersonate != "" {
+			req.Header.Set("Impersonate-User", c.Impersonate)
+		}
+		ctx := credentials.WithCredential(req.Context(), c)
+		if c.TraceParent != "" {
+			ctx = tracing.ContextWithTraceParent(ctx, c.TraceParent)
+		}
+		h.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
+
 // Helper function used by recovering dependent watches and owner ref injection.
+// The owner is the one the token of the request was issued for, if any. It
+// includes the namespace of the owner, which is required when creating the
+// reconcile requests.
 func getRequestOwnerRef(req *http.Request) (*kubeconfig.NamespacedOwnerReference, error) {
-	owner := kubeconfig.NamespacedOwnerReference{}
-	user, _, ok := req.BasicAuth()
-	if !ok {
+	c, ok := credentials.FromContext(req.Context())
+	if !ok || c.Owner.Kind == "" {
 		return nil, nil
 	}
-	authString, err := base64.StdEncoding.DecodeString(user)
-	if err != nil {
-		m := "Could not base64 decode username"
-		log.Error(err, m)
-		return &owner, err
-	}
-	// Set owner to NamespacedOwnerReference, which has metav1.OwnerReference
-	// as a subset along with the Namespace of the owner. Please see the
-	// kubeconfig.NamespacedOwnerReference type for more information. The
-	// namespace is required when creating the reconcile requests.
-	if err := json.Unmarshal(authString, &owner); err != nil {
-		m := "Could not unmarshal auth string"
-		log.Error(err, m)
-		return &owner, err
-	}
-	return &owner, err
+	return &c.Owner, nil
 }
 
 func getGVKFromRequestInfo(r *k8sRequest.RequestInfo, restMapper meta.RESTMapper) (schema.GroupVersionKind, error) {
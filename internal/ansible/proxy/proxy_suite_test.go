@@ -18,10 +18,12 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/controllermap"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/credentials"
 	kcorev1 "k8s.io/api/core/v1"
 	kmetav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
@@ -34,6 +36,9 @@ var testMgr manager.Manager
 
 var testClient client.Client
 
+// testCredentials issues the tokens the tests authenticate to the proxy with.
+var testCredentials = credentials.NewStore(time.Hour)
+
 func TestProxy(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Proxy Test Suite")
@@ -63,6 +68,7 @@ var _ = BeforeSuite(func() {
 		ControllerMap:     cMap,
 		WatchedNamespaces: map[string]cache.Config{"test-watched-namespace": {}},
 		OwnerInjection:    true,
+		Credentials:       testCredentials,
 	})
 	if err != nil {
 		Fail(fmt.Sprintf("Error starting proxy: %v", err))
@@ -26,6 +26,8 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 
 	kcorev1 "k8s.io/api/core/v1"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/credentials"
 )
 
 var _ = Describe("proxyTests", func() {
@@ -45,7 +47,18 @@ var _ = Describe("proxyTests", func() {
 			}
 		}()
 
-		resp, err := http.Get("http://localhost:8888/api/v1/namespaces/test-watched-namespace/pods/test")
+		token, err := testCredentials.Issue(credentials.Credential{Ident: "test"})
+		if err != nil {
+			t.Fatalf("Failed to issue a token: %v", err)
+		}
+		defer testCredentials.Revoke(token)
+		req, err := http.NewRequest(http.MethodGet,
+			"http://localhost:8888/api/v1/namespaces/test-watched-namespace/pods/test", nil)
+		if err != nil {
+			t.Fatalf("Failed to create http request: %v", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		resp, err := http.DefaultClient.Do(req)
 		if err != nil {
 			t.Fatalf("Error getting pod from proxy: %v", err)
 		}
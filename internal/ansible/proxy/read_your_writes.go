@@ -0,0 +1,205 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/felixge/httpsnoop"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/set"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/credentials"
+	k8sRequest "github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/requestfactory"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/writes"
+)
+
+// writePollInterval - how often the cache is checked for the writes of a run.
+const writePollInterval = 10 * time.Millisecond
+
+var errUnsupportedMediaType = errors.New("unsupported media type")
+
+// recordWrites - records the resourceVersions of the objects the runs
+// successfully create or update through h, with their status, and the
+// objects they delete, to the writes of their run. Dry runs, as in check
+// mode, are not recorded.
+func recordWrites(h http.Handler, tracker *writes.Tracker) http.Handler {
+	rf := k8sRequest.RequestInfoFactory{APIPrefixes: set.New("api", "apis"),
+		GrouplessAPIPrefixes: set.New("api")}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c, ok := credentials.FromContext(req.Context())
+		if !ok || c.Ident == "" || req.URL.Query().Has("dryRun") {
+			h.ServeHTTP(w, req)
+			return
+		}
+		switch req.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		default:
+			h.ServeHTTP(w, req)
+			return
+		}
+		r, err := rf.NewRequestInfo(req)
+		if err != nil || !r.IsResourceRequest || (r.Subresource != "" && r.Subresource != "status") {
+			h.ServeHTTP(w, req)
+			return
+		}
+		resource := schema.GroupResource{Group: r.APIGroup, Resource: r.Resource}
+
+		// the written object is returned in the response, with the
+		// resourceVersion and, if generated, the name it was written with.
+		code, body := serveCapturingResponse(h, w, req)
+		if code < http.StatusOK || code >= http.StatusMultipleChoices {
+			return
+		}
+		obj, err := decodeWrittenObject(w.Header(), body)
+
+		// a deleted object is returned as last written if kept until its
+		// finalizers are done, which the cache observes. It is otherwise
+		// waited for to be absent from the cache.
+		if req.Method == http.MethodDelete {
+			if r.Name == "" {
+				return
+			}
+			key := writes.Key{GroupResource: resource, Namespace: r.Namespace, Name: r.Name}
+			if err == nil && obj.GetKind() != "Status" && obj.GetDeletionTimestamp() != nil {
+				tracker.Record(c.Ident, key, obj.GetResourceVersion())
+			} else {
+				tracker.RecordDeletion(c.Ident, key)
+			}
+			return
+		}
+
+		if err != nil {
+			log.V(1).Info("Could not decode the written object", "path", req.URL.Path, "error", err.Error())
+			return
+		}
+		tracker.Record(c.Ident, writes.Key{
+			GroupResource: resource,
+			Namespace:     r.Namespace,
+			Name:          obj.GetName(),
+		}, obj.GetResourceVersion())
+	})
+}
+
+// serveCapturingResponse serves req with h, and returns the status code and
+// the body of the response.
+func serveCapturingResponse(h http.Handler, w http.ResponseWriter, req *http.Request) (int, []byte) {
+	code := http.StatusOK
+	body := &bytes.Buffer{}
+	h.ServeHTTP(httpsnoop.Wrap(w, httpsnoop.Hooks{
+		WriteHeader: func(next httpsnoop.WriteHeaderFunc) httpsnoop.WriteHeaderFunc {
+			return func(c int) {
+				code = c
+				next(c)
+			}
+		},
+		Write: func(next httpsnoop.WriteFunc) httpsnoop.WriteFunc {
+			return func(b []byte) (int, error) {
+				body.Write(b)
+				return next(b)
+			}
+		},
+		ReadFrom: func(next httpsnoop.ReadFromFunc) httpsnoop.ReadFromFunc {
+			return func(src io.Reader) (int64, error) {
+				return next(io.TeeReader(src, body))
+			}
+		},
+	}), req)
+	return code, body.Bytes()
+}
+
+// decodeWrittenObject decodes the object of a JSON response, compressed if
+// encoded with gzip.
+func decodeWrittenObject(header http.Header, body []byte) (*unstructured.Unstructured, error) {
+	if mediaType, _, err := mime.ParseMediaType(header.Get("Content-Type")); err != nil ||
+		mediaType != "application/json" {
+		return nil, errUnsupportedMediaType
+	}
+	if header.Get("Content-Encoding") == "gzip" {
+		zr, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		if body, err = io.ReadAll(zr); err != nil {
+			return nil, err
+		}
+	}
+	obj := &unstructured.Unstructured{}
+	if err := json.Unmarshal(body, &obj.Object); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// waitForWrites - waits until the cache has observed the writes of the run
+// of req to the objects requested, and the deletions of those it deleted,
+// and returns whether it did within the timeout of the handler. The requests
+// of the runs not tracked return immediately. The cache observed a write
+// once it holds the object at the resourceVersion written or a later one,
+// compared as integers as documented by the writes package.
+func (c *cacheResponseHandler) waitForWrites(req *http.Request, r *k8sRequest.RequestInfo,
+	k schema.GroupVersionKind) bool {
+	cred, ok := credentials.FromContext(req.Context())
+	if !ok || cred.Ident == "" {
+		return true
+	}
+	written := c.writes.Written(cred.Ident, schema.GroupResource{Group: r.APIGroup, Resource: r.Resource},
+		r.Namespace, r.Name)
+	if len(written) == 0 {
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), c.writeTimeout)
+	defer cancel()
+	err := wait.PollUntilContextCancel(ctx, writePollInterval, true, func(ctx context.Context) (bool, error) {
+		for key, write := range written {
+			un := &unstructured.Unstructured{}
+			un.SetGroupVersionKind(k)
+			obj := client.ObjectKey{Namespace: key.Namespace, Name: key.Name}
+			err := c.informerCache.Get(ctx, obj, un)
+			if write.Deleted {
+				if !apierrors.IsNotFound(err) {
+					return false, nil
+				}
+				delete(written, key)
+				continue
+			}
+			if err != nil {
+				return false, nil
+			}
+			cached, err := strconv.ParseInt(un.GetResourceVersion(), 10, 64)
+			if err != nil || cached < write.ResourceVersion {
+				return false, nil
+			}
+			delete(written, key)
+		}
+		return true, nil
+	})
+	return err == nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/set"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/credentials"
+	k8sRequest "github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/requestfactory"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/writes"
+)
+
+// laggingCache - a cache holding the config maps at the resourceVersion it
+// is set to, if any.
+type laggingCache struct {
+	cache.Cache
+	resourceVersion atomic.Int64
+}
+
+func (c *laggingCache) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	rv := c.resourceVersion.Load()
+	if rv == 0 {
+		return apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, key.Name)
+	}
+	obj.(*unstructured.Unstructured).SetResourceVersion(strconv.FormatInt(rv, 10))
+	return nil
+}
+
+var _ = Describe("read your writes", func() {
+	configMaps := schema.GroupResource{Resource: "configmaps"}
+	written := `{"kind":"ConfigMap","metadata":{"name":"example-x7k2","resourceVersion":"42"}}`
+	run := credentials.Credential{Ident: "1234"}
+	withRun := func(req *http.Request) *http.Request {
+		return req.WithContext(credentials.WithCredential(req.Context(), run))
+	}
+
+	DescribeTable("recordWrites",
+		func(method, path, contentEncoding, response string, code int, expected map[writes.Key]writes.Write) {
+			tracker := writes.NewTracker()
+			tracker.Start(run.Ident)
+			tracker.Record(run.Ident, writes.Key{GroupResource: configMaps, Namespace: "default", Name: "deleted"}, "1")
+			apiServer := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				body := []byte(response)
+				if contentEncoding == "gzip" {
+					buf := &bytes.Buffer{}
+					zw := gzip.NewWriter(buf)
+					_, _ = zw.Write(body)
+					_ = zw.Close()
+					body = buf.Bytes()
+					w.Header().Set("Content-Encoding", "gzip")
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(code)
+				_, _ = w.Write(body)
+			})
+			req := withRun(httptest.NewRequest(method, path, strings.NewReader(`{}`)))
+			recordWrites(apiServer, tracker).ServeHTTP(httptest.NewRecorder(), req)
+			Expect(tracker.Written(run.Ident, configMaps, "", "")).To(Equal(expected))
+		},
+		Entry("create with a generated name", http.MethodPost, "/api/v1/namespaces/default/configmaps", "",
+			written, http.StatusCreated, map[writes.Key]writes.Write{
+				{GroupResource: configMaps, Namespace: "default", Name: "deleted"}:      {ResourceVersion: 1},
+				{GroupResource: configMaps, Namespace: "default", Name: "example-x7k2"}: {ResourceVersion: 42},
+			}),
+		Entry("compressed patch", http.MethodPatch, "/api/v1/namespaces/default/configmaps/example-x7k2", "gzip",
+			written, http.StatusOK, map[writes.Key]writes.Write{
+				{GroupResource: configMaps, Namespace: "default", Name: "deleted"}:      {ResourceVersion: 1},
+				{GroupResource: configMaps, Namespace: "default", Name: "example-x7k2"}: {ResourceVersion: 42},
+			}),
+		Entry("failed update", http.MethodPut, "/api/v1/namespaces/default/configmaps/example-x7k2", "",
+			written, http.StatusConflict, map[writes.Key]writes.Write{
+				{GroupResource: configMaps, Namespace: "default", Name: "deleted"}: {ResourceVersion: 1},
+			}),
+		Entry("dry run", http.MethodPost, "/api/v1/namespaces/default/configmaps?dryRun=All", "",
+			written, http.StatusCreated, map[writes.Key]writes.Write{
+				{GroupResource: configMaps, Namespace: "default", Name: "deleted"}: {ResourceVersion: 1},
+			}),
+		Entry("delete", http.MethodDelete, "/api/v1/namespaces/default/configmaps/deleted", "",
+			`{"kind":"Status","status":"Success"}`, http.StatusOK, map[writes.Key]writes.Write{
+				{GroupResource: configMaps, Namespace: "default", Name: "deleted"}: {ResourceVersion: 1, Deleted: true},
+			}),
+		Entry("delete pending finalizers", http.MethodDelete, "/api/v1/namespaces/default/configmaps/deleted", "",
+			`{"kind":"ConfigMap","metadata":{"name":"deleted","resourceVersion":"43",`+
+				`"deletionTimestamp":"2026-01-01T00:00:00Z"}}`, http.StatusOK, map[writes.Key]writes.Write{
+				{GroupResource: configMaps, Namespace: "default", Name: "deleted"}: {ResourceVersion: 43},
+			}),
+	)
+
+	Describe("waitForWrites", func() {
+		var (
+			informerCache *laggingCache
+			handler       *cacheResponseHandler
+			tracker       *writes.Tracker
+			info          *k8sRequest.RequestInfo
+			req           *http.Request
+		)
+		gvk := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+
+		BeforeEach(func() {
+			informerCache = &laggingCache{}
+			tracker = writes.NewTracker()
+			tracker.Start(run.Ident)
+			tracker.Record(run.Ident, writes.Key{GroupResource: configMaps, Namespace: "default", Name: "example"}, "42")
+			handler = &cacheResponseHandler{informerCache: informerCache, writes: tracker,
+				writeTimeout: 200 * time.Millisecond}
+			req = withRun(httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/default/configmaps", nil))
+			rf := k8sRequest.RequestInfoFactory{APIPrefixes: set.New("api", "apis"),
+				GrouplessAPIPrefixes: set.New("api")}
+			var err error
+			info, err = rf.NewRequestInfo(req)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should wait for the cache to observe the writes of the run", func() {
+			informerCache.resourceVersion.Store(41)
+			time.AfterFunc(50*time.Millisecond, func() { informerCache.resourceVersion.Store(42) })
+			Expect(handler.waitForWrites(req, info, gvk)).To(BeTrue())
+		})
+
+		It("Should wait for the cache to observe the deletions of the run", func() {
+			tracker.RecordDeletion(run.Ident, writes.Key{GroupResource: configMaps, Namespace: "default",
+				Name: "example"})
+			informerCache.resourceVersion.Store(42)
+			time.AfterFunc(50*time.Millisecond, func() { informerCache.resourceVersion.Store(0) })
+			Expect(handler.waitForWrites(req, info, gvk)).To(BeTrue())
+		})
+
+		It("Should give up once the timeout passed", func() {
+			informerCache.resourceVersion.Store(41)
+			Expect(handler.waitForWrites(req, info, gvk)).To(BeFalse())
+		})
+
+		It("Should not wait for the reads of other runs", func() {
+			other := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/default/configmaps", nil)
+			other = other.WithContext(credentials.WithCredential(other.Context(), credentials.Credential{Ident: "5678"}))
+			Expect(handler.waitForWrites(other, info, gvk)).To(BeTrue())
+		})
+	})
+})
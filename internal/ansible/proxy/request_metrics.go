@@ -0,0 +1,56 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"net/http"
+
+	"github.com/felixge/httpsnoop"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/set"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/metrics"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/cacheoutcome"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/credentials"
+	k8sRequest "github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/requestfactory"
+)
+
+// observeRequests - counts the requests served by h, and observes how long
+// they take, by verb, resource, owner GVK and cache outcome. The cache
+// outcome is the one set by the cache handler, or skip if the request did not
+// reach it.
+func observeRequests(h http.Handler) http.Handler {
+	rf := k8sRequest.RequestInfoFactory{APIPrefixes: set.New("api", "apis"),
+		GrouplessAPIPrefixes: set.New("api")}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var verb, resource, gvk string
+		if r, err := rf.NewRequestInfo(req); err == nil {
+			verb, resource = r.Verb, r.Resource
+			if r.Subresource != "" {
+				resource += "/" + r.Subresource
+			}
+		}
+		if c, ok := credentials.FromContext(req.Context()); ok && c.Owner.Kind != "" {
+			gvk = schema.FromAPIVersionAndKind(c.Owner.APIVersion, c.Owner.Kind).String()
+		}
+		ctx := cacheoutcome.WithOutcome(req.Context())
+		m := httpsnoop.CaptureMetrics(h, w, req.WithContext(ctx))
+		outcome := cacheoutcome.FromContext(ctx)
+		if outcome == "" {
+			outcome = metrics.CacheSkip
+		}
+		metrics.ProxyRequest(verb, resource, gvk, outcome, m.Duration)
+	})
+}
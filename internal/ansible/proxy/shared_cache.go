@@ -0,0 +1,134 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proxy
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// errSharedInformer - the informer is not removed, being used by the
+// controllers or the client of the manager.
+var errSharedInformer = errors.New("informer shared with the manager")
+
+// SharedCache - the cache of the manager, which the proxy shares with the
+// controllers and the client of the manager. It records the resources whose
+// informers are used through it, and does not remove their informers. The
+// proxy uses the informers of the dependent resources through Unwrap, so
+// that it may remove those no longer used.
+type SharedCache struct {
+	cache.Cache
+	scheme *runtime.Scheme
+
+	mutex  sync.Mutex
+	shared map[schema.GroupVersionKind]bool
+}
+
+// NewSharedCache - wraps the cache of the manager, whose objects are of the
+// types of scheme.
+func NewSharedCache(c cache.Cache, scheme *runtime.Scheme) *SharedCache {
+	return &SharedCache{Cache: c, scheme: scheme, shared: map[schema.GroupVersionKind]bool{}}
+}
+
+// Unwrap - returns the cache, whose informers are not recorded as shared.
+func (c *SharedCache) Unwrap() cache.Cache {
+	return c.Cache
+}
+
+// Get - records the resource of obj as shared, and gets obj from the cache.
+func (c *SharedCache) Get(ctx context.Context, key client.ObjectKey, obj client.Object,
+	opts ...client.GetOption) error {
+	if err := c.share(obj); err != nil {
+		return err
+	}
+	return c.Cache.Get(ctx, key, obj, opts...)
+}
+
+// List - records the resource of list as shared, and lists it from the
+// cache.
+func (c *SharedCache) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if err := c.share(list); err != nil {
+		return err
+	}
+	return c.Cache.List(ctx, list, opts...)
+}
+
+// GetInformer - records the resource of obj as shared, and returns its
+// informer.
+func (c *SharedCache) GetInformer(ctx context.Context, obj client.Object,
+	opts ...cache.InformerGetOption) (cache.Informer, error) {
+	if err := c.share(obj); err != nil {
+		return nil, err
+	}
+	return c.Cache.GetInformer(ctx, obj, opts...)
+}
+
+// GetInformerForKind - records the resource of gvk as shared, and returns
+// its informer.
+func (c *SharedCache) GetInformerForKind(ctx context.Context, gvk schema.GroupVersionKind,
+	opts ...cache.InformerGetOption) (cache.Informer, error) {
+	c.mutex.Lock()
+	c.shared[gvk] = true
+	c.mutex.Unlock()
+	return c.Cache.GetInformerForKind(ctx, gvk, opts...)
+}
+
+// IndexField - records the resource of obj as shared, and indexes it.
+func (c *SharedCache) IndexField(ctx context.Context, obj client.Object, field string,
+	extractValue client.IndexerFunc) error {
+	if err := c.share(obj); err != nil {
+		return err
+	}
+	return c.Cache.IndexField(ctx, obj, field, extractValue)
+}
+
+// RemoveInformer - removes the informer of the resource of obj, unless
+// shared, in which case errSharedInformer is returned.
+func (c *SharedCache) RemoveInformer(ctx context.Context, obj client.Object) error {
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return err
+	}
+	// the informer is not shared while removed.
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.shared[gvk] {
+		return errSharedInformer
+	}
+	return c.Cache.RemoveInformer(ctx, obj)
+}
+
+// share records the resource of obj as shared.
+func (c *SharedCache) share(obj runtime.Object) error {
+	gvk, err := apiutil.GVKForObject(obj, c.scheme)
+	if err != nil {
+		return err
+	}
+	if _, ok := obj.(client.ObjectList); ok {
+		gvk.Kind = strings.TrimSuffix(gvk.Kind, "List")
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.shared[gvk] = true
+	return nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package writes tracks the resourceVersions of the objects each run writes
+// through the proxy, and the objects it deletes, so that the reads of a run
+// served from the cache reflect its own writes.
+//
+// The API server documents resourceVersions as opaque strings, to be
+// compared for equality only. The resourceVersions are nonetheless compared
+// as integers, to tell whether the cache observed a write or a later one: those
+// of the API server are the revisions of etcd, which increase with each
+// write. The resourceVersions that are not integers, as with another
+// storage, are not recorded, and the reads of their objects are not waited
+// for.
+package writes
+
+import (
+	"strconv"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Key - an object written by a run.
+type Key struct {
+	schema.GroupResource
+	Namespace string
+	Name      string
+}
+
+// Write - the last write of an object by a run.
+type Write struct {
+	// ResourceVersion is the resourceVersion the object was written with,
+	// unless deleted.
+	ResourceVersion int64
+	// Deleted tells whether the run deleted the object, which the cache
+	// reflects once the object is absent from it.
+	Deleted bool
+}
+
+// Tracker - tracks the writes of the runs it was started for. A nil Tracker
+// tracks nothing.
+type Tracker struct {
+	mutex sync.Mutex
+	runs  map[string]map[Key]Write
+}
+
+// NewTracker - creates a tracker.
+func NewTracker() *Tracker {
+	return &Tracker{runs: map[string]map[Key]Write{}}
+}
+
+// Start - starts tracking the writes of the run of ident.
+func (t *Tracker) Start(ident string) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.runs[ident] = map[Key]Write{}
+}
+
+// Stop - stops tracking the writes of the run of ident, and forgets them.
+func (t *Tracker) Stop(ident string) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	delete(t.runs, ident)
+}
+
+// Record - records the resourceVersion of an object written by the run of
+// ident, if started. The resourceVersions that are not integers are not
+// recorded.
+func (t *Tracker) Record(ident string, key Key, resourceVersion string) {
+	if t == nil {
+		return
+	}
+	rv, err := strconv.ParseInt(resourceVersion, 10, 64)
+	if err != nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if written, ok := t.runs[ident]; ok {
+		// an older response of a concurrent write neither replaces the
+		// write, nor the deletion, it preceded.
+		if w, ok := written[key]; ok && w.ResourceVersion >= rv {
+			return
+		}
+		written[key] = Write{ResourceVersion: rv}
+	}
+}
+
+// RecordDeletion - records that the run of ident, if started, deleted an
+// object.
+func (t *Tracker) RecordDeletion(ident string, key Key) {
+	if t == nil {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if written, ok := t.runs[ident]; ok {
+		written[key] = Write{ResourceVersion: written[key].ResourceVersion, Deleted: true}
+	}
+}
+
+// Written - the last writes of the objects of resource the run of ident
+// wrote or deleted, in namespace, or in all namespaces if empty, restricted
+// to the object named name, if set.
+func (t *Tracker) Written(ident string, resource schema.GroupResource, namespace, name string) map[Key]Write {
+	if t == nil {
+		return nil
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	var written map[Key]Write
+	for key, w := range t.runs[ident] {
+		if key.GroupResource != resource || (namespace != "" && key.Namespace != namespace) ||
+			(name != "" && key.Name != name) {
+			continue
+		}
+		if written == nil {
+			written = map[Key]Write{}
+		}
+		written[key] = w
+	}
+	return written
+}
@@ -0,0 +1,112 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package writes
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var configMaps = schema.GroupResource{Resource: "configmaps"}
+
+func TestTracker(t *testing.T) {
+	first := Key{GroupResource: configMaps, Namespace: "default", Name: "first"}
+	second := Key{GroupResource: configMaps, Namespace: "other", Name: "second"}
+	deployment := Key{GroupResource: schema.GroupResource{Group: "apps", Resource: "deployments"},
+		Namespace: "default", Name: "first"}
+
+	tracker := NewTracker()
+	// not started.
+	tracker.Record("1", first, "10")
+	tracker.Start("1")
+	if written := tracker.Written("1", configMaps, "", ""); len(written) != 0 {
+		t.Fatalf("Unexpected writes %v recorded before the run started", written)
+	}
+
+	tracker.Record("1", first, "12")
+	// an older response of a concurrent write.
+	tracker.Record("1", first, "11")
+	tracker.Record("1", second, "20")
+	tracker.Record("1", deployment, "30")
+	tracker.Record("1", deployment, "not-an-integer")
+
+	testCases := []struct {
+		name      string
+		namespace string
+		objName   string
+		expected  map[Key]Write
+	}{
+		{
+			name:     "all namespaces",
+			expected: map[Key]Write{first: {ResourceVersion: 12}, second: {ResourceVersion: 20}},
+		},
+		{
+			name:      "namespace",
+			namespace: "other",
+			expected:  map[Key]Write{second: {ResourceVersion: 20}},
+		},
+		{
+			name:      "object",
+			namespace: "default",
+			objName:   "first",
+			expected:  map[Key]Write{first: {ResourceVersion: 12}},
+		},
+		{
+			name:      "not written",
+			namespace: "default",
+			objName:   "second",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			written := tracker.Written("1", configMaps, tc.namespace, tc.objName)
+			if !reflect.DeepEqual(written, tc.expected) {
+				t.Fatalf("Unexpected writes %v, expected %v", written, tc.expected)
+			}
+		})
+	}
+
+	// the deletion is kept, and not replaced by an older write.
+	tracker.RecordDeletion("1", first)
+	tracker.Record("1", first, "11")
+	deleted := map[Key]Write{first: {ResourceVersion: 12, Deleted: true}}
+	if written := tracker.Written("1", configMaps, "default", ""); !reflect.DeepEqual(written, deleted) {
+		t.Fatalf("Unexpected writes %v after the object was deleted", written)
+	}
+	// the object created again.
+	tracker.Record("1", first, "13")
+	recreated := map[Key]Write{first: {ResourceVersion: 13}}
+	if written := tracker.Written("1", configMaps, "default", ""); !reflect.DeepEqual(written, recreated) {
+		t.Fatalf("Unexpected writes %v after the object was created again", written)
+	}
+	tracker.Stop("1")
+	if written := tracker.Written("1", configMaps, "", ""); len(written) != 0 {
+		t.Fatalf("Unexpected writes %v after the run stopped", written)
+	}
+}
+
+func TestNilTracker(t *testing.T) {
+	var tracker *Tracker
+	key := Key{GroupResource: configMaps, Namespace: "default", Name: "example"}
+	tracker.Start("1")
+	tracker.Record("1", key, "1")
+	tracker.RecordDeletion("1", key)
+	if written := tracker.Written("1", configMaps, "", ""); written != nil {
+		t.Fatalf("Unexpected writes %v", written)
+	}
+	tracker.Stop("1")
+}
@@ -0,0 +1,348 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redact masks sensitive values before they are written to logs,
+// ansible-runner artifacts, status messages or handed to event handlers.
+//
+// Sensitive values come from two places: the JSON paths a watch marks as
+// sensitive (evaluated against the custom resource being reconciled), and the
+// data and stringData of any Secret object seen while masking.
+package redact
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/jsonpath"
+	"sigs.k8s.io/yaml"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+const (
+	// Mask replaces every sensitive value.
+	Mask = "********"
+
+	// minValueLength is the shortest value that is masked wherever it appears
+	// in free text. Shorter values would mask unrelated output, so they are
+	// only masked where they are found structurally.
+	minValueLength = 4
+)
+
+// Redactor knows which values of a custom resource are sensitive. A nil
+// Redactor is valid and only masks Secret data.
+type Redactor struct {
+	// mu guards paths, since a JSONPath is not safe for concurrent use.
+	mu    sync.Mutex
+	paths []*jsonpath.JSONPath
+}
+
+// New returns a Redactor for the given JSON paths. Paths may be written as
+// kubectl JSONPath templates ("{.spec.password}") or as plain dotted paths
+// ("spec.password", "$.spec.users[*].token").
+func New(paths []string) (*Redactor, error) {
+	r := &Redactor{}
+	for _, p := range paths {
+		jp := jsonpath.New(p).AllowMissingKeys(true)
+		if err := jp.Parse(toTemplate(p)); err != nil {
+			return nil, fmt.Errorf("invalid redact path %q: %w", p, err)
+		}
+		r.paths = append(r.paths, jp)
+	}
+	return r, nil
+}
+
+// toTemplate converts a dotted path into a JSONPath template.
+func toTemplate(path string) string {
+	path = strings.TrimSpace(path)
+	if strings.HasPrefix(path, "{") {
+		return path
+	}
+	path = strings.TrimPrefix(path, "$")
+	if !strings.HasPrefix(path, ".") && !strings.HasPrefix(path, "[") {
+		path = "." + path
+	}
+	return "{" + path + "}"
+}
+
+// ForObject returns a Masker holding the sensitive values found in u.
+func (r *Redactor) ForObject(u *unstructured.Unstructured) *Masker {
+	m := &Masker{values: map[string]struct{}{}}
+	if u == nil {
+		return m
+	}
+	if r != nil {
+		r.mu.Lock()
+		for _, jp := range r.paths {
+			results, err := jp.FindResults(u.Object)
+			if err != nil {
+				continue
+			}
+			for _, result := range results {
+				for _, v := range result {
+					m.addValues(v.Interface())
+				}
+			}
+		}
+		r.mu.Unlock()
+	}
+	m.collectSecrets(u.Object)
+	return m
+}
+
+// Body masks a request or response body, which may be JSON or YAML. Values at
+// the redact paths are taken from the body itself. Bodies that cannot be
+// parsed are replaced entirely.
+func (r *Redactor) Body(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	j, err := yaml.YAMLToJSON(body)
+	if err != nil {
+		return fmt.Sprintf("<%d bytes redacted>", len(body))
+	}
+	var obj interface{}
+	if err := json.Unmarshal(j, &obj); err != nil {
+		return fmt.Sprintf("<%d bytes redacted>", len(body))
+	}
+	m := &Masker{values: map[string]struct{}{}}
+	if o, ok := obj.(map[string]interface{}); ok {
+		m = r.ForObject(&unstructured.Unstructured{Object: o})
+	}
+	masked, err := json.Marshal(m.Value(obj))
+	if err != nil {
+		return fmt.Sprintf("<%d bytes redacted>", len(body))
+	}
+	return string(masked)
+}
+
+// Masker replaces the sensitive values of a single run. It learns the values
+// of any Secret it is asked to mask, so later output containing them is
+// masked as well. A Masker is safe for concurrent use.
+type Masker struct {
+	mu       sync.RWMutex
+	values   map[string]struct{}
+	replacer *strings.Replacer
+}
+
+// String masks every sensitive value found in s.
+func (m *Masker) String(s string) string {
+	if m == nil || s == "" {
+		return s
+	}
+	m.mu.RLock()
+	replacer := m.replacer
+	m.mu.RUnlock()
+	if replacer == nil {
+		return s
+	}
+	return replacer.Replace(s)
+}
+
+// Value returns a copy of v with sensitive values masked. v is expected to be
+// made of the types produced by encoding/json.
+func (m *Masker) Value(v interface{}) interface{} {
+	if m == nil {
+		return v
+	}
+	m.collectSecrets(v)
+	return m.mask(v)
+}
+
+// Map is Value for a JSON object.
+func (m *Masker) Map(in map[string]interface{}) map[string]interface{} {
+	if in == nil {
+		return nil
+	}
+	out, _ := m.Value(in).(map[string]interface{})
+	return out
+}
+
+// Event returns a copy of e with its data and stdout masked.
+func (m *Masker) Event(e eventapi.JobEvent) eventapi.JobEvent {
+	if m == nil {
+		return e
+	}
+	// Mask the data first so that Secrets returned by a task are known
+	// before its stdout is masked.
+	e.EventData = m.Map(e.EventData)
+	e.StdOut = m.String(e.StdOut)
+	return e
+}
+
+// JSON masks a JSON document, returning it as text.
+func (m *Masker) JSON(b []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return m.String(string(b))
+	}
+	masked, err := json.Marshal(m.Value(v))
+	if err != nil {
+		return m.String(string(b))
+	}
+	return string(masked)
+}
+
+// Add masks the given values wherever they appear, such as the credentials
+// given to a run that are not part of its custom resource.
+func (m *Masker) Add(values ...string) {
+	if m == nil {
+		return
+	}
+	m.addValues(values)
+}
+
+func (m *Masker) mask(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		secret := isSecret(v)
+		out := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			if secret && (key == "data" || key == "stringData") {
+				out[key] = maskAll(val)
+				continue
+			}
+			out[key] = m.mask(val)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, val := range v {
+			out[i] = m.mask(val)
+		}
+		return out
+	case string:
+		return m.String(v)
+	default:
+		return v
+	}
+}
+
+// maskAll replaces every value of a Secret's data or stringData.
+func maskAll(v interface{}) interface{} {
+	data, ok := v.(map[string]interface{})
+	if !ok {
+		return Mask
+	}
+	out := make(map[string]interface{}, len(data))
+	for key := range data {
+		out[key] = Mask
+	}
+	return out
+}
+
+// collectSecrets adds the data of every Secret found in v.
+func (m *Masker) collectSecrets(v interface{}) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if isSecret(v) {
+			if data, ok := v["data"].(map[string]interface{}); ok {
+				for _, val := range data {
+					s, ok := val.(string)
+					if !ok {
+						continue
+					}
+					m.addValues(s)
+					if decoded, err := base64.StdEncoding.DecodeString(s); err == nil {
+						m.addValues(string(decoded))
+					}
+				}
+			}
+			m.addValues(v["stringData"])
+		}
+		for _, val := range v {
+			m.collectSecrets(val)
+		}
+	case []interface{}:
+		for _, val := range v {
+			m.collectSecrets(val)
+		}
+	}
+}
+
+// addValues adds every string found in v to the values masked in free text.
+func (m *Masker) addValues(v interface{}) {
+	var found []string
+	var walk func(reflect.Value)
+	walk = func(rv reflect.Value) {
+		for rv.Kind() == reflect.Interface || rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				return
+			}
+			rv = rv.Elem()
+		}
+		switch rv.Kind() {
+		case reflect.String:
+			if len(rv.String()) >= minValueLength {
+				found = append(found, rv.String())
+			}
+		case reflect.Map:
+			for _, k := range rv.MapKeys() {
+				walk(rv.MapIndex(k))
+			}
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < rv.Len(); i++ {
+				walk(rv.Index(i))
+			}
+		}
+	}
+	walk(reflect.ValueOf(v))
+	if len(found) == 0 {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	added := false
+	for _, s := range found {
+		if _, ok := m.values[s]; !ok {
+			m.values[s] = struct{}{}
+			added = true
+		}
+	}
+	if added {
+		m.replacer = newReplacer(m.values)
+	}
+}
+
+// newReplacer builds a replacer that prefers the longest value, so a value
+// that contains another is masked as a whole.
+func newReplacer(values map[string]struct{}) *strings.Replacer {
+	sorted := make([]string, 0, len(values))
+	for v := range values {
+		sorted = append(sorted, v)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if len(sorted[i]) != len(sorted[j]) {
+			return len(sorted[i]) > len(sorted[j])
+		}
+		return sorted[i] < sorted[j]
+	})
+	oldnew := make([]string, 0, 2*len(sorted))
+	for _, v := range sorted {
+		oldnew = append(oldnew, v, Mask)
+	}
+	return strings.NewReplacer(oldnew...)
+}
+
+func isSecret(obj map[string]interface{}) bool {
+	apiVersion, _ := obj["apiVersion"].(string)
+	return obj["kind"] == "Secret" && (apiVersion == "v1" || apiVersion == "")
+}
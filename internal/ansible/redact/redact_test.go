@@ -0,0 +1,216 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redact
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+func newCR() *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "app.example.com/v1alpha1",
+		"kind":       "Example",
+		"metadata": map[string]interface{}{
+			"name":      "example",
+			"namespace": "default",
+		},
+		"spec": map[string]interface{}{
+			"size":     int64(3),
+			"password": "hunter22",
+			"users": []interface{}{
+				map[string]interface{}{"name": "alice", "token": "alice-token"},
+				map[string]interface{}{"name": "bobby", "token": "bobby-token"},
+			},
+		},
+	}}
+}
+
+func TestNew(t *testing.T) {
+	testCases := []struct {
+		name        string
+		paths       []string
+		shouldError bool
+	}{
+		{name: "no paths"},
+		{name: "dotted path", paths: []string{"spec.password"}},
+		{name: "rooted path", paths: []string{"$.spec.users[*].token"}},
+		{name: "template", paths: []string{"{.spec.password}"}},
+		{name: "invalid path", paths: []string{"spec.users[*"}, shouldError: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := New(tc.paths)
+			if tc.shouldError && err == nil {
+				t.Fatalf("expected an error for paths %v", tc.paths)
+			}
+			if !tc.shouldError && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestMaskerString(t *testing.T) {
+	r, err := New([]string{"spec.password", "spec.users[*].token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := r.ForObject(newCR())
+
+	got := m.String("login with hunter22, alice-token and bobby-token as alice")
+	want := "login with " + Mask + ", " + Mask + " and " + Mask + " as alice"
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestMaskerShortValues(t *testing.T) {
+	cr := newCR()
+	cr.Object["spec"].(map[string]interface{})["password"] = "abc"
+	r, err := New([]string{"spec.password"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := r.ForObject(cr)
+	if got := m.String("abcdef"); got != "abcdef" {
+		t.Fatalf("short value should not be masked in free text, got %q", got)
+	}
+}
+
+func TestMaskerAdd(t *testing.T) {
+	var r *Redactor
+	m := r.ForObject(newCR())
+	m.Add("run-token")
+
+	got := m.String("ANSIBLE_OPERATOR_RUN_TOKEN=run-token")
+	if want := "ANSIBLE_OPERATOR_RUN_TOKEN=" + Mask; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	env := m.Map(map[string]interface{}{"ANSIBLE_OPERATOR_RUN_TOKEN": "run-token"})
+	if env["ANSIBLE_OPERATOR_RUN_TOKEN"] != Mask {
+		t.Fatalf("expected the token to be masked, got %v", env)
+	}
+}
+
+func TestMaskerSecrets(t *testing.T) {
+	var r *Redactor
+	m := r.ForObject(newCR())
+
+	data := map[string]interface{}{
+		"res": map[string]interface{}{
+			"resources": []interface{}{
+				map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "Secret",
+					"data":       map[string]interface{}{"password": "c2VjcmV0LXZhbHVl"},
+					"stringData": map[string]interface{}{"key": "plain-text-key"},
+				},
+			},
+		},
+	}
+	want := map[string]interface{}{
+		"res": map[string]interface{}{
+			"resources": []interface{}{
+				map[string]interface{}{
+					"apiVersion": "v1",
+					"kind":       "Secret",
+					"data":       map[string]interface{}{"password": Mask},
+					"stringData": map[string]interface{}{"key": Mask},
+				},
+			},
+		},
+	}
+	if got := m.Map(data); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	// Values learnt from the Secret are masked in later output, both encoded
+	// and decoded.
+	got := m.String("c2VjcmV0LXZhbHVl secret-value plain-text-key")
+	if strings.Contains(got, "secret-value") || strings.Contains(got, "c2VjcmV0") ||
+		strings.Contains(got, "plain-text-key") {
+		t.Fatalf("secret data was not masked: %q", got)
+	}
+}
+
+func TestMaskerEvent(t *testing.T) {
+	r, err := New([]string{"spec.password"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := r.ForObject(newCR())
+
+	e := eventapi.JobEvent{
+		Event:  eventapi.EventRunnerOnFailed,
+		StdOut: "fatal: bad password hunter22",
+		EventData: map[string]interface{}{
+			"res": map[string]interface{}{"msg": "password hunter22 rejected"},
+		},
+	}
+	got := m.Event(e)
+	if got.StdOut != "fatal: bad password "+Mask {
+		t.Fatalf("unexpected stdout %q", got.StdOut)
+	}
+	if msg := got.GetFailedPlaybookMessage(); msg != "password "+Mask+" rejected" {
+		t.Fatalf("unexpected failure message %q", msg)
+	}
+	if e.EventData["res"].(map[string]interface{})["msg"] != "password hunter22 rejected" {
+		t.Fatalf("original event was modified")
+	}
+}
+
+func TestRedactorBody(t *testing.T) {
+	r, err := New([]string{"spec.password"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "custom resource",
+			body: `{"apiVersion":"app.example.com/v1alpha1","kind":"Example","spec":{"password":"hunter22"}}`,
+			want: `{"apiVersion":"app.example.com/v1alpha1","kind":"Example","spec":{"password":"` + Mask + `"}}`,
+		},
+		{
+			name: "yaml secret",
+			body: "apiVersion: v1\nkind: Secret\nstringData:\n  key: value\n",
+			want: `{"apiVersion":"v1","kind":"Secret","stringData":{"key":"` + Mask + `"}}`,
+		},
+		{
+			name: "empty",
+		},
+		{
+			name: "unparsable",
+			body: "\t{not: [valid",
+			want: "<13 bytes redacted>",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := r.Body([]byte(tc.body)); got != tc.want {
+				t.Fatalf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
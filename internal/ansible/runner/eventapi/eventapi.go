@@ -28,150 +28,279 @@ import (
 
 	"github.com/go-logr/logr"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/metrics"
 )
 
-// EventReceiver serves the event API
-type EventReceiver struct {
-	// Events is the channel used by the event API handler to send JobEvents
-	// back to the runner, or whatever code is using this receiver.
-	Events chan JobEvent
+// eventsPath is the path under which the events of each run are received, at
+// eventsPath + ident + "/".
+const eventsPath = "/events/"
 
+// Server serves the event API of every run on a single unix socket, and
+// passes the events of each run to the EventReceiver registered for it.
+type Server struct {
 	// SocketPath is the path on the filesystem to a unix streaming socket
 	SocketPath string
 
-	// URLPath is the path portion of the url at which events should be
-	// received. For example, "/events/"
-	URLPath string
+	// server is the http.Server instance that serves the event API.
+	server *http.Server
 
-	// server is the http.Server instance that serves the event API. It must be
-	// closed.
-	server io.Closer
+	// receivers holds the receiver of each run, by ident.
+	receivers map[string]*EventReceiver
 
-	// stopped indicates if this receiver has permanently stopped receiving
-	// events. When true, requests to POST an event will receive a "410 Gone"
-	// response, and the body will be ignored.
-	stopped bool
-
-	// mutex controls access to the "stopped" bool above, ensuring that writes
-	// are goroutine-safe.
+	// mutex controls access to receivers.
 	mutex sync.RWMutex
 
-	// ident is the unique identifier for a particular run of ansible-runner
-	ident string
-
-	// logger holds a logger that has some fields already set
 	logger logr.Logger
 }
 
-func New(ident string, errChan chan<- error) (*EventReceiver, error) {
-	sockPath := fmt.Sprintf("/tmp/ansibleoperator-%s", ident)
+// NewServer starts serving the event API on a unix socket at sockPath. The
+// server must be closed.
+func NewServer(sockPath string) (*Server, error) {
+	// a socket left behind by a previous process would make Listen fail.
+	if err := os.Remove(sockPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
 	listener, err := net.Listen("unix", sockPath)
 	if err != nil {
 		return nil, err
 	}
 
-	rec := EventReceiver{
-		Events:     make(chan JobEvent, 1000),
+	s := &Server{
 		SocketPath: sockPath,
-		URLPath:    "/events/",
-		ident:      ident,
-		logger:     logf.Log.WithName("eventapi").WithValues("job", ident),
+		receivers:  map[string]*EventReceiver{},
+		logger:     logf.Log.WithName("eventapi"),
 	}
-
 	mux := http.NewServeMux()
-	mux.HandleFunc(rec.URLPath, rec.handleEvents)
-	srv := http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
-	rec.server = &srv
+	mux.HandleFunc(eventsPath, s.handleEvents)
+	s.server = &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
 
 	go func() {
-		errChan <- srv.Serve(listener)
+		// http.Server returns this in the case of being closed cleanly
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error(err, "Error from event API")
+		}
 	}()
-	return &rec, nil
+	return s, nil
 }
 
-// Close ensures that appropriate resources are cleaned up, such as any unix
-// streaming socket that may be in use. Close must be called.
-func (e *EventReceiver) Close() {
-	e.mutex.Lock()
-	e.stopped = true
-	e.mutex.Unlock()
-	e.logger.V(1).Info("Event API stopped")
-	if err := e.server.Close(); err != nil && !errors.Is(err, os.ErrClosed) {
-		e.logger.Error(err, "Failed to close event receiver")
+// Register returns a receiver for the events of the run with the given ident.
+// The receiver must be closed once the run has finished.
+func (s *Server) Register(ident string) (*EventReceiver, error) {
+	if ident == "" || strings.Contains(ident, "/") {
+		return nil, fmt.Errorf("invalid run ident %q", ident)
 	}
-	os.Remove(e.SocketPath)
-	close(e.Events)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.receivers[ident]; ok {
+		return nil, fmt.Errorf("a run with ident %s is already registered", ident)
+	}
+	rec := &EventReceiver{
+		Events:     make(chan JobEvent, 1000),
+		SocketPath: s.SocketPath,
+		URLPath:    eventsPath + ident + "/",
+		stop:       make(chan struct{}),
+		ident:      ident,
+		server:     s,
+		logger:     s.logger.WithValues("job", ident),
+	}
+	s.receivers[ident] = rec
+	return rec, nil
+}
+
+// Close stops serving the event API and removes the socket.
+func (s *Server) Close() error {
+	err := s.server.Close()
+	if rmErr := os.Remove(s.SocketPath); rmErr != nil && !errors.Is(rmErr, os.ErrNotExist) {
+		err = errors.Join(err, rmErr)
+	}
+	return err
+}
+
+func (s *Server) unregister(ident string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.receivers, ident)
 }
 
-func (e *EventReceiver) handleEvents(w http.ResponseWriter, r *http.Request) {
-	if r.URL.Path != e.URLPath {
+func (s *Server) handleEvents(w http.ResponseWriter, r *http.Request) {
+	ident := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, eventsPath), "/")
+	if ident == "" || strings.Contains(ident, "/") {
 		http.NotFound(w, r)
-		e.logger.Info("Path not found", "code", "404", "Request.Path", r.URL.Path)
+		s.logger.Info("Path not found", "code", "404", "Request.Path", r.URL.Path)
 		return
 	}
+	logger := s.logger.WithValues("job", ident)
 
 	if r.Method != http.MethodPost {
-		e.logger.Info("Method not allowed", "code", "405", "Request.Method", r.Method)
+		logger.Info("Method not allowed", "code", "405", "Request.Method", r.Method)
 		w.WriteHeader(http.StatusMethodNotAllowed)
 		return
 	}
 
 	ct := r.Header.Get("content-type")
 	if strings.Split(ct, ";")[0] != "application/json" {
-		e.logger.Info("Wrong content type", "code", "415", "Request.Content-Type", ct)
+		logger.Info("Wrong content type", "code", "415", "Request.Content-Type", ct)
 		w.WriteHeader(http.StatusUnsupportedMediaType)
 		if _, err := w.Write([]byte("The content-type must be \"application/json\"")); err != nil {
-			e.logger.Error(err, "Failed to write response body")
+			logger.Error(err, "Failed to write response body")
 		}
 		return
 	}
 
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
-		e.logger.Error(err, "Could not read request body", "code", "500")
+		logger.Error(err, "Could not read request body", "code", "500")
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	event := JobEvent{}
+	event := eventBody{}
 	err = json.Unmarshal(body, &event)
 	if err != nil {
-		e.logger.Info("Could not deserialize body.", "code", "400", "Error", err)
+		logger.Info("Could not deserialize body.", "code", "400", "Error", err)
 		w.WriteHeader(http.StatusBadRequest)
 		if _, err := w.Write([]byte("Could not deserialize body as JSON")); err != nil {
-			e.logger.Error(err, "Failed to write response body")
+			logger.Error(err, "Failed to write response body")
 		}
 		return
 	}
 
-	// Guarantee that the Events channel will not be written to if stopped ==
-	// true, because in that case the channel has been closed.
+	s.mutex.RLock()
+	rec, ok := s.receivers[ident]
+	s.mutex.RUnlock()
+	if !ok {
+		metrics.EventDropped("unknown_run")
+		w.WriteHeader(http.StatusGone)
+		logger.Info("No run is accepting events for this job", "code", "410")
+		return
+	}
+	rec.receive(w, r, event, body)
+}
+
+// eventBody is the body of a request, which is either a JobEvent or a status
+// event.
+type eventBody struct {
+	JobEvent
+	Status string `json:"status"`
+}
+
+// EventReceiver receives the events of a single run
+type EventReceiver struct {
+	// Events is the channel used by the event API handler to send JobEvents
+	// back to the runner, or whatever code is using this receiver.
+	Events chan JobEvent
+
+	// SocketPath is the path on the filesystem to a unix streaming socket
+	SocketPath string
+
+	// URLPath is the path portion of the url at which events should be
+	// received. For example, "/events/1234/"
+	URLPath string
+
+	// stopped indicates if this receiver has permanently stopped receiving
+	// events. When true, requests to POST an event will receive a "410 Gone"
+	// response, and the body will be ignored.
+	stopped bool
+
+	// status is the last status reported by ansible-runner, such as "running"
+	// or "successful".
+	status string
+
+	// mutex controls access to the "stopped" bool and "status" above, ensuring
+	// that writes are goroutine-safe.
+	mutex sync.RWMutex
+
+	// stop is closed when the receiver is closed, releasing the requests
+	// waiting for room in Events.
+	stop chan struct{}
+
+	// pending counts the requests sending to Events, which must be done
+	// before Events is closed.
+	pending sync.WaitGroup
+
+	// ident is the unique identifier for a particular run of ansible-runner
+	ident string
+
+	// server is the server the receiver is registered with.
+	server *Server
+
+	// logger holds a logger that has some fields already set
+	logger logr.Logger
+}
+
+// Status returns the last status reported by ansible-runner, or an empty
+// string if it has not reported any.
+func (e *EventReceiver) Status() string {
 	e.mutex.RLock()
 	defer e.mutex.RUnlock()
+	return e.status
+}
+
+// Close stops receiving events for the run and closes the Events channel.
+// Close must be called.
+func (e *EventReceiver) Close() {
+	e.mutex.Lock()
+	if e.stopped {
+		e.mutex.Unlock()
+		return
+	}
+	e.stopped = true
+	close(e.stop)
+	e.mutex.Unlock()
+	e.server.unregister(e.ident)
+	e.pending.Wait()
+	e.logger.V(1).Info("Event API stopped")
+	close(e.Events)
+}
+
+func (e *EventReceiver) receive(w http.ResponseWriter, r *http.Request, event eventBody, body []byte) {
+	// Guarantee that the Events channel will not be written to if stopped ==
+	// true, because in that case the channel is being closed.
+	e.mutex.Lock()
 	if e.stopped {
-		e.mutex.RUnlock()
+		e.mutex.Unlock()
+		metrics.EventDropped("stopped")
 		w.WriteHeader(http.StatusGone)
 		e.logger.Info("Stopped and not accepting additional events for this job", "code", "410")
 		return
 	}
 	// ansible-runner sends "status events" and "ansible events". The "status
-	// events" signify a change in the state of ansible-runner itself, which
-	// we're not currently interested in.
+	// events" signify a change in the state of ansible-runner itself, of
+	// which only the status is kept.
 	// https://ansible-runner.readthedocs.io/en/latest/external_interface.html#event-structure
 	if event.UUID == "" {
-		e.logger.V(1).Info("Dropping event that is not a JobEvent")
-		e.logger.V(2).Info("Dropped event", "event", event, "request", string(body))
-	} else {
-		// timeout if the channel blocks for too long
-		timeout := time.NewTimer(10 * time.Second)
-		select {
-		case e.Events <- event:
-		case <-timeout.C:
-			e.logger.Info("Timed out writing event to channel", "code", "500")
-			w.WriteHeader(http.StatusInternalServerError)
-			return
+		if event.Status != "" {
+			e.status = event.Status
+			e.logger.V(1).Info("Received status event", "status", event.Status)
+		} else {
+			e.logger.V(1).Info("Dropping event that is not a JobEvent")
+			e.logger.V(2).Info("Dropped event", "event", event.JobEvent, "request", string(body))
 		}
-		_ = timeout.Stop()
+		e.mutex.Unlock()
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	e.pending.Add(1)
+	e.mutex.Unlock()
+	defer e.pending.Done()
+
+	// Rather than dropping events when the run is slow to take them, the
+	// response is held back, which holds back ansible.
+	metrics.EventQueued()
+	defer metrics.EventDequeued()
+	select {
+	case e.Events <- event.JobEvent:
+		metrics.EventProcessed()
+	case <-e.stop:
+		metrics.EventDropped("stopped")
+		w.WriteHeader(http.StatusGone)
+		e.logger.Info("Stopped and not accepting additional events for this job", "code", "410")
+		return
+	case <-r.Context().Done():
+		metrics.EventDropped("canceled")
+		e.logger.Info("Request canceled before the event was received")
+		return
 	}
 	w.WriteHeader(http.StatusNoContent)
 }
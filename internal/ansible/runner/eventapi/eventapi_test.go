@@ -0,0 +1,142 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventapi
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestServer(t *testing.T) (*Server, *http.Client) {
+	t.Helper()
+	server, err := NewServer(filepath.Join(t.TempDir(), "events.sock"))
+	if err != nil {
+		t.Fatalf("Unable to start server: %v", err)
+	}
+	t.Cleanup(func() { _ = server.Close() })
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, "unix", server.SocketPath)
+		},
+	}}
+	return server, client
+}
+
+func post(t *testing.T, client *http.Client, path, body string) int {
+	t.Helper()
+	resp, err := client.Post("http://localhost"+path, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Unable to post event: %v", err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestServerDemultiplexes(t *testing.T) {
+	server, client := newTestServer(t)
+	first, err := server.Register("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := server.Register("2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := server.Register("1"); err == nil {
+		t.Fatalf("Expected an error registering the same ident twice")
+	}
+
+	if code := post(t, client, first.URLPath, `{"uuid":"a","event":"runner_on_ok"}`); code != http.StatusNoContent {
+		t.Fatalf("Unexpected status code %d", code)
+	}
+	if code := post(t, client, second.URLPath, `{"uuid":"b","event":"runner_on_failed"}`); code != http.StatusNoContent {
+		t.Fatalf("Unexpected status code %d", code)
+	}
+	if code := post(t, client, first.URLPath, `{"status":"successful"}`); code != http.StatusNoContent {
+		t.Fatalf("Unexpected status code %d", code)
+	}
+	if code := post(t, client, "/events/3/", `{"uuid":"c"}`); code != http.StatusGone {
+		t.Fatalf("Unexpected status code %d for an unknown run", code)
+	}
+
+	if e := <-first.Events; e.UUID != "a" {
+		t.Fatalf("Unexpected event %v for the first run", e)
+	}
+	if e := <-second.Events; e.UUID != "b" {
+		t.Fatalf("Unexpected event %v for the second run", e)
+	}
+	if status := first.Status(); status != "successful" {
+		t.Fatalf("Unexpected status %q", status)
+	}
+
+	first.Close()
+	if _, ok := <-first.Events; ok {
+		t.Fatalf("Expected the events of a closed receiver to be closed")
+	}
+	if code := post(t, client, first.URLPath, `{"uuid":"d"}`); code != http.StatusGone {
+		t.Fatalf("Unexpected status code %d for a finished run", code)
+	}
+	second.Close()
+}
+
+func TestReceiverBackpressure(t *testing.T) {
+	server, client := newTestServer(t)
+	rec, err := server.Register("1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// fill the buffer, so that the next event has to wait.
+	for i := 0; i < cap(rec.Events); i++ {
+		rec.Events <- JobEvent{}
+	}
+
+	codes := make(chan int)
+	go func() {
+		codes <- post(t, client, rec.URLPath, `{"uuid":"a"}`)
+	}()
+	select {
+	case code := <-codes:
+		t.Fatalf("Expected the event to wait for room, got status code %d", code)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	for i := 0; i < cap(rec.Events); i++ {
+		<-rec.Events
+	}
+	if e := <-rec.Events; e.UUID != "a" {
+		t.Fatalf("Unexpected event %v", e)
+	}
+	if code := <-codes; code != http.StatusNoContent {
+		t.Fatalf("Unexpected status code %d", code)
+	}
+
+	// closing the receiver releases the events still waiting.
+	for i := 0; i < cap(rec.Events); i++ {
+		rec.Events <- JobEvent{}
+	}
+	go func() {
+		codes <- post(t, client, rec.URLPath, `{"uuid":"b"}`)
+	}()
+	time.Sleep(50 * time.Millisecond)
+	rec.Close()
+	if code := <-codes; code != http.StatusGone {
+		t.Fatalf("Unexpected status code %d for an event waiting when the run finished", code)
+	}
+}
@@ -25,14 +25,24 @@ const (
 
 	// EventPlaybookOnTaskStart - playbook is starting to run a task.
 	EventPlaybookOnTaskStart = "playbook_on_task_start"
+	// EventPlaybookOnHandlerTaskStart - playbook is starting to run a handler.
+	EventPlaybookOnHandlerTaskStart = "playbook_on_handler_task_start"
 	// EventRunnerOnOk - task finished with ok status.
 	EventRunnerOnOk = "runner_on_ok"
 	// EventRunnerOnFailed - task finished with failed status.
 	EventRunnerOnFailed = "runner_on_failed"
 	// EventPlaybookOnStats - playbook has finished running.
 	EventPlaybookOnStats = "playbook_on_stats"
+	// EventRunnerOnSkipped - task was skipped.
+	EventRunnerOnSkipped = "runner_on_skipped"
+	// EventRunnerOnUnreachable - task could not reach its host.
+	EventRunnerOnUnreachable = "runner_on_unreachable"
 	// EventRunnerItemOnOk - item finished with ok status.
 	EventRunnerItemOnOk = "runner_item_on_ok"
+	// EventRunnerItemOnFailed - item finished with failed status.
+	EventRunnerItemOnFailed = "runner_item_on_failed"
+	// EventRunnerItemOnSkipped - item was skipped.
+	EventRunnerItemOnSkipped = "runner_item_on_skipped"
 
 	// Ansible Task Actions
 
@@ -40,6 +50,9 @@ const (
 	TaskActionSetFact = "set_fact"
 	// TaskActionDebug - task action of printing a debug message.
 	TaskActionDebug = "debug"
+	// TaskActionSetOutput - task action of setting outputs to be passed to the
+	// next run.
+	TaskActionSetOutput = "operator_sdk.util.set_output"
 
 	// defaultFailedMessage - Default failed playbook message
 	defaultFailedMessage = "unknown playbook failure"
@@ -113,6 +126,23 @@ func (je JobEvent) GetFailedPlaybookMessage() string {
 	return message
 }
 
+// GetOutputs - get the outputs set by a successful set_output task from
+// res.outputs.
+func (je JobEvent) GetOutputs() (map[string]interface{}, bool) {
+	if je.Event != EventRunnerOnOk {
+		return nil, false
+	}
+	if module := je.EventData["task_action"]; module != TaskActionSetOutput && module != "set_output" {
+		return nil, false
+	}
+	result, ok := je.EventData["res"].(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	outputs, ok := result["outputs"].(map[string]interface{})
+	return outputs, ok
+}
+
 // IgnoreError - Does the job event contain the ignore_error ansible flag
 func (je JobEvent) IgnoreError() bool {
 	ignoreErrors, ok := je.EventData["ignore_errors"]
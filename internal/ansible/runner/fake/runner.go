@@ -15,6 +15,7 @@
 package fake
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -37,17 +38,32 @@ type Runner struct {
 	JobEvents []eventapi.JobEvent
 	//Stdout standard out to reply if failure occurs.
 	Stdout string
+	// PreviousOutputs records the previous outputs given to the last run.
+	PreviousOutputs map[string]interface{}
+	// LastSuccessfulRun records the result of the last successful run given
+	// to the last run.
+	LastSuccessfulRun map[string]interface{}
+	// RC is the exit code the runs finish with.
+	RC int
+	// Status is the status the runs finish with. It is successful by default
+	// when RC is zero, failed otherwise.
+	Status runner.RunStatus
 }
 
 type runResult struct {
-	events <-chan eventapi.JobEvent
-	stdout string
+	events  <-chan eventapi.JobEvent
+	stdout  string
+	outcome runner.Outcome
 }
 
 func (r *runResult) Events() <-chan eventapi.JobEvent {
 	return r.events
 }
 
+func (r *runResult) Wait() (runner.Outcome, error) {
+	return r.outcome, nil
+}
+
 func (r *runResult) Stdout() (string, error) {
 	if r.stdout != "" {
 		return r.stdout, nil
@@ -56,10 +72,20 @@ func (r *runResult) Stdout() (string, error) {
 }
 
 // Run - runs the fake runner.
-func (r *Runner) Run(_ string, u *unstructured.Unstructured, _ string) (runner.RunResult, error) {
+func (r *Runner) Run(_ context.Context, _ string, u *unstructured.Unstructured, _, _ string,
+	previousOutputs, lastSuccessfulRun map[string]interface{}) (runner.RunResult, error) {
+	r.PreviousOutputs = previousOutputs
+	r.LastSuccessfulRun = lastSuccessfulRun
 	if r.Error != nil {
 		return nil, r.Error
 	}
+	outcome := runner.Outcome{RC: r.RC, Status: r.Status, StartTime: time.Now()}
+	if outcome.Status == "" {
+		outcome.Status = runner.RunSuccessful
+		if r.RC != 0 {
+			outcome.Status = runner.RunFailed
+		}
+	}
 	c := make(chan eventapi.JobEvent)
 	go func() {
 		for _, je := range r.JobEvents {
@@ -67,7 +93,8 @@ func (r *Runner) Run(_ string, u *unstructured.Unstructured, _ string) (runner.R
 		}
 		close(c)
 	}()
-	return &runResult{events: c, stdout: r.Stdout}, nil
+	outcome.EndTime = time.Now()
+	return &runResult{events: c, stdout: r.Stdout, outcome: outcome}, nil
 }
 
 // GetReconcilePeriod - new reconcile period.
@@ -25,6 +25,8 @@ import (
 
 	"github.com/spf13/afero"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/redact"
 )
 
 var log = logf.Log.WithName("inputdir")
@@ -99,6 +101,55 @@ func (i *InputDir) Stdout(ident string) (string, error) {
 	return string(errorText), err
 }
 
+// Redact masks sensitive values in the extravars and envvars and in the stdout
+// and job events of the run that corresponds to the given ident. It must only be
+// called once ansible-runner has exited.
+func (i *InputDir) Redact(ident string, m *redact.Masker) error {
+	paramBytes, err := json.Marshal(m.Map(i.Parameters))
+	if err != nil {
+		return err
+	}
+	if err := i.addFile("env/extravars", paramBytes); err != nil {
+		return err
+	}
+	envVars := make(map[string]string, len(i.EnvVars))
+	for k, v := range i.EnvVars {
+		envVars[k] = m.String(v)
+	}
+	envVarBytes, err := json.Marshal(envVars)
+	if err != nil {
+		return err
+	}
+	if err := i.addFile("env/envvars", envVarBytes); err != nil {
+		return err
+	}
+
+	artifactsPath := filepath.Join(i.Path, "artifacts", ident)
+	stdoutPath := filepath.Join(artifactsPath, "stdout")
+	if stdout, err := os.ReadFile(stdoutPath); err == nil {
+		if err := os.WriteFile(stdoutPath, []byte(m.String(string(stdout))), 0644); err != nil {
+			return err
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	eventFiles, err := filepath.Glob(filepath.Join(artifactsPath, "job_events", "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, path := range eventFiles {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(m.JSON(b)), 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Write commits the object's state to the filesystem at i.Path.
 func (i *InputDir) Write() error {
 	paramBytes, err := json.Marshal(i.Parameters)
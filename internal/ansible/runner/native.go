@@ -0,0 +1,171 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mattn/go-shellwords"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+// Executor - the program that runs a playbook or role.
+type Executor string
+
+const (
+	// AnsibleRunnerExecutor - runs playbooks and roles with ansible-runner,
+	// which posts events through the ansible_runner_http plugin.
+	AnsibleRunnerExecutor Executor = "ansible-runner"
+	// AnsiblePlaybookExecutor - runs playbooks and roles with ansible-playbook
+	// directly, which posts events through a bundled callback plugin.
+	AnsiblePlaybookExecutor Executor = "ansible-playbook"
+
+	callbackPluginName = "ansible_operator"
+)
+
+var (
+	//go:embed plugins/ansible_operator.py
+	callbackPlugin []byte
+	//go:embed plugins/role.yaml
+	rolePlaybook []byte
+)
+
+// nativePlaybookCmdFunc runs a playbook with ansible-playbook.
+func nativePlaybookCmdFunc(path string, args []string) cmdFuncType {
+	return func(ident, inputDirPath string, maxArtifacts, verbosity int) *exec.Cmd {
+		return nativeCmd(inputDirPath, verbosity, path, args)
+	}
+}
+
+// nativeRoleCmdFunc runs a role with ansible-playbook, through a playbook
+// that imports the role.
+func nativeRoleCmdFunc(path string, args []string) cmdFuncType {
+	return func(ident, inputDirPath string, maxArtifacts, verbosity int) *exec.Cmd {
+		args := append([]string{"-e", "ansible_operator_role=" + path}, args...)
+		return nativeCmd(inputDirPath, verbosity, filepath.Join(inputDirPath, "project", "role.yaml"), args)
+	}
+}
+
+func nativeCmd(inputDirPath string, verbosity int, playbook string, args []string) *exec.Cmd {
+	cmdArgs := []string{
+		"-i", filepath.Join(inputDirPath, "inventory"),
+		"-e", "@" + filepath.Join(inputDirPath, "env", "extravars"),
+	}
+	if verbosity > 0 {
+		cmdArgs = append(cmdArgs, ansibleVerbosityString(verbosity))
+	}
+	cmdArgs = append(cmdArgs, args...)
+	cmdArgs = append(cmdArgs, playbook)
+	return exec.Command(string(AnsiblePlaybookExecutor), cmdArgs...)
+}
+
+// splitAnsibleArgs splits the extra arguments given to ansible into words as a
+// shell would, so that quoted values such as -e "a=b c" are kept whole. The
+// single quotes the whole value may be wrapped in are trimmed.
+func splitAnsibleArgs(ansibleArgs string) ([]string, error) {
+	args, err := shellwords.Parse(ansibleArgs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ansible args %q: %w", ansibleArgs, err)
+	}
+	if len(args) == 1 && strings.HasPrefix(ansibleArgs, "'") {
+		return splitAnsibleArgs(args[0])
+	}
+	return args, nil
+}
+
+// writeNativeFiles adds the callback plugin and the playbook used to run
+// roles to the input directory.
+func writeNativeFiles(inputDirPath string) error {
+	pluginDir := filepath.Join(inputDirPath, "callback_plugins")
+	if err := os.MkdirAll(pluginDir, os.ModePerm); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(pluginDir, callbackPluginName+".py"), callbackPlugin, 0644); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(inputDirPath, "project", "role.yaml"), rolePlaybook, 0644)
+}
+
+// nativeEnv returns the environment enabling the callback plugin, which posts
+// events to the receiver. Callback plugins already enabled are kept.
+func nativeEnv(inputDirPath string, receiver *eventapi.EventReceiver) []string {
+	return []string{
+		"ANSIBLE_CALLBACK_PLUGINS=" + joinEnvList(filepath.Join(inputDirPath, "callback_plugins"),
+			os.Getenv("ANSIBLE_CALLBACK_PLUGINS"), ":"),
+		"ANSIBLE_CALLBACKS_ENABLED=" + joinEnvList(callbackPluginName, os.Getenv("ANSIBLE_CALLBACKS_ENABLED"), ","),
+		"ANSIBLE_OPERATOR_EVENTS_SOCKET=" + receiver.SocketPath,
+		"ANSIBLE_OPERATOR_EVENTS_PATH=" + receiver.URLPath,
+	}
+}
+
+func joinEnvList(value, existing, sep string) string {
+	if existing == "" {
+		return value
+	}
+	return value + sep + existing
+}
+
+// writeNativeArtifacts keeps the output of ansible-playbook as the stdout of
+// the run, like ansible-runner does, and removes the artifacts of the oldest
+// runs so that at most maxArtifacts are kept. Zero keeps every run.
+func writeNativeArtifacts(inputDirPath, ident string, output []byte, maxArtifacts int) error {
+	artifactsPath := filepath.Join(inputDirPath, "artifacts")
+	runPath := filepath.Join(artifactsPath, ident)
+	if err := os.MkdirAll(runPath, os.ModePerm); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(runPath, "stdout"), output, 0644); err != nil {
+		return err
+	}
+	if maxArtifacts <= 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(artifactsPath)
+	if err != nil {
+		return err
+	}
+	type run struct {
+		path    string
+		modTime int64
+	}
+	runs := []run{}
+	for _, e := range entries {
+		// "latest" is a symlink to the last run.
+		if !e.IsDir() || e.Name() == ident {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return err
+		}
+		runs = append(runs, run{path: filepath.Join(artifactsPath, e.Name()), modTime: info.ModTime().UnixNano()})
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].modTime > runs[j].modTime })
+	// the current run is one of the runs kept.
+	for i := maxArtifacts - 1; i < len(runs); i++ {
+		if err := os.RemoveAll(runs[i].path); err != nil {
+			return fmt.Errorf("failed to remove artifacts of run %s: %w", filepath.Base(runs[i].path), err)
+		}
+	}
+	return nil
+}
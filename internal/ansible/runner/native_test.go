@@ -0,0 +1,205 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runner
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/watches"
+)
+
+func TestNativeCmdFunc(t *testing.T) {
+	args, err := splitAnsibleArgs("'--tags deploy'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		name     string
+		cmdFunc  cmdFuncType
+		expected []string
+	}{
+		{
+			name:    "playbook",
+			cmdFunc: nativePlaybookCmdFunc("/opt/ansible/playbook.yml", args),
+			expected: []string{"ansible-playbook", "-i", "/tmp/in/inventory", "-e", "@/tmp/in/env/extravars",
+				"-vv", "--tags", "deploy", "/opt/ansible/playbook.yml"},
+		},
+		{
+			name:    "role",
+			cmdFunc: nativeRoleCmdFunc("/opt/ansible/roles/memcached", args),
+			expected: []string{"ansible-playbook", "-i", "/tmp/in/inventory", "-e", "@/tmp/in/env/extravars",
+				"-vv", "-e", "ansible_operator_role=/opt/ansible/roles/memcached", "--tags", "deploy",
+				"/tmp/in/project/role.yaml"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := tc.cmdFunc("1234", "/tmp/in", 20, 2)
+			if !reflect.DeepEqual(cmd.Args, tc.expected) {
+				t.Fatalf("Unexpected args\n%v\nexpected\n%v", cmd.Args, tc.expected)
+			}
+		})
+	}
+}
+
+func TestSplitAnsibleArgs(t *testing.T) {
+	testCases := []struct {
+		args        string
+		expected    []string
+		shouldError bool
+	}{
+		{args: "", expected: []string{}},
+		{args: "--tags deploy", expected: []string{"--tags", "deploy"}},
+		{args: "'--tags deploy'", expected: []string{"--tags", "deploy"}},
+		{args: `-e "a=b c" --tags 'x y'`, expected: []string{"-e", "a=b c", "--tags", "x y"}},
+		{args: `'-e "a=b c"'`, expected: []string{"-e", "a=b c"}},
+		{args: `-e "a=b`, shouldError: true},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.args, func(t *testing.T) {
+			args, err := splitAnsibleArgs(tc.args)
+			if tc.shouldError {
+				if err == nil {
+					t.Fatalf("Expected an error, got %v", args)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(args) != len(tc.expected) || (len(args) > 0 && !reflect.DeepEqual(args, tc.expected)) {
+				t.Fatalf("Unexpected args %q, expected %q", args, tc.expected)
+			}
+		})
+	}
+}
+
+func TestNativeRunEnv(t *testing.T) {
+	// a fake ansible-playbook that keeps the environment it was run with.
+	dir := t.TempDir()
+	envPath := filepath.Join(dir, "env")
+	script := "#!/bin/sh\nenv > " + envPath + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "ansible-playbook"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	gvk := schema.GroupVersionKind{Group: "app.example.com", Version: "v1alpha1", Kind: "Example"}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Unable to get working directory: %v", err)
+	}
+	w := watches.New(gvk, "", filepath.Join(cwd, "testdata", "playbook.yml"), nil, nil)
+	r, err := New(*w, "", AnsiblePlaybookExecutor, map[string]string{"PYTHONPATH": "/opt/shim"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	u.SetName("native-env")
+	u.SetNamespace("default")
+	result, err := r.Run(context.Background(), "1234", u, "/tmp/kubeconfig", "run-token", nil, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for range result.Events() {
+	}
+	if _, err := result.Wait(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	env, err := os.ReadFile(envPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{RunTokenEnvVar + "=run-token", "KUBECONFIG=/tmp/kubeconfig", "PYTHONPATH=/opt/shim"} {
+		if !strings.Contains(string(env), expected+"\n") {
+			t.Fatalf("Expected %s in the environment of the run, got:\n%s", expected, env)
+		}
+	}
+}
+
+func TestNewExecutor(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "app.example.com", Version: "v1alpha1", Kind: "Example"}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Unable to get working directory: %v", err)
+	}
+	w := watches.New(gvk, "", filepath.Join(cwd, "testdata", "playbook.yml"), nil, nil)
+
+	r, err := New(*w, "", AnsiblePlaybookExecutor, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	cmd := r.(*runner).cmdFunc("1234", "/tmp/in", 20, 0)
+	if cmd.Args[0] != string(AnsiblePlaybookExecutor) {
+		t.Fatalf("Unexpected command %v", cmd.Args)
+	}
+
+	r, err = New(*w, "", "", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if r.(*runner).executor != AnsibleRunnerExecutor {
+		t.Fatalf("Unexpected default executor %q", r.(*runner).executor)
+	}
+
+	if _, err := New(*w, "", "ansible-navigator", nil); err == nil {
+		t.Fatalf("Expected an error for an unknown executor")
+	}
+}
+
+func TestWriteNativeArtifacts(t *testing.T) {
+	dir := t.TempDir()
+	artifacts := filepath.Join(dir, "artifacts")
+	old := time.Now().Add(-time.Hour)
+	for i, ident := range []string{"1", "2", "3"} {
+		if err := os.MkdirAll(filepath.Join(artifacts, ident), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		modTime := old.Add(time.Duration(i) * time.Minute)
+		if err := os.Chtimes(filepath.Join(artifacts, ident), modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := writeNativeArtifacts(dir, "4", []byte("PLAY RECAP"), 2); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	stdout, err := os.ReadFile(filepath.Join(artifacts, "4", "stdout"))
+	if err != nil || string(stdout) != "PLAY RECAP" {
+		t.Fatalf("Unexpected stdout %q: %v", stdout, err)
+	}
+	entries, err := os.ReadDir(artifacts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kept := []string{}
+	for _, e := range entries {
+		kept = append(kept, e.Name())
+	}
+	if expected := []string{"3", "4"}; !reflect.DeepEqual(kept, expected) {
+		t.Fatalf("Unexpected artifacts kept %v, expected %v", kept, expected)
+	}
+}
@@ -0,0 +1,189 @@
+# Copyright 2026 The Operator-SDK Authors
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+DOCUMENTATION = '''
+    name: ansible_operator
+    type: aggregate
+    short_description: Posts job events to the ansible-operator event API
+    description:
+      - Posts an event for every playbook, play, task and result to the event
+        receiver of ansible-operator, in the format used by ansible-runner.
+      - Used when ansible-operator runs ansible-playbook directly, without
+        ansible-runner.
+    requirements:
+      - ANSIBLE_OPERATOR_EVENTS_SOCKET, the unix socket of the event receiver
+      - ANSIBLE_OPERATOR_EVENTS_PATH, the URL path events are posted to
+'''
+
+import datetime
+import http.client
+import json
+import os
+import socket
+import uuid
+
+from ansible.plugins.callback import CallbackBase
+from ansible.vars.clean import module_response_deepcopy, strip_internal_keys
+
+
+class UnixHTTPConnection(http.client.HTTPConnection):
+    def __init__(self, path):
+        super(UnixHTTPConnection, self).__init__('localhost')
+        self.socket_path = path
+
+    def connect(self):
+        sock = socket.socket(socket.AF_UNIX, socket.SOCK_STREAM)
+        sock.connect(self.socket_path)
+        self.sock = sock
+
+
+class CallbackModule(CallbackBase):
+    CALLBACK_VERSION = 2.0
+    CALLBACK_TYPE = 'aggregate'
+    CALLBACK_NAME = 'ansible_operator'
+    CALLBACK_NEEDS_ENABLED = True
+
+    def __init__(self):
+        super(CallbackModule, self).__init__()
+        self.socket_path = os.environ.get('ANSIBLE_OPERATOR_EVENTS_SOCKET')
+        self.url_path = os.environ.get('ANSIBLE_OPERATOR_EVENTS_PATH', '/events/')
+        self.counter = 0
+        self.playbook = ''
+        self.playbook_uuid = str(uuid.uuid4())
+        self.play = None
+        # the start time of each task, by task uuid.
+        self.task_start = {}
+
+    def _send(self, event, event_data, stdout=''):
+        if not self.socket_path:
+            return
+        self.counter += 1
+        data = {'playbook': self.playbook, 'playbook_uuid': self.playbook_uuid}
+        if self.play is not None:
+            data['play'] = self.play.get_name()
+            data['play_uuid'] = str(self.play._uuid)
+        data.update(event_data)
+        created = datetime.datetime.now(datetime.timezone.utc).replace(tzinfo=None)
+        body = json.dumps({
+            'uuid': str(uuid.uuid4()),
+            'counter': self.counter,
+            'stdout': stdout,
+            'event': event,
+            'event_data': data,
+            'pid': os.getpid(),
+            'created': created.isoformat() + '+00:00',
+        }, default=str)
+        conn = UnixHTTPConnection(self.socket_path)
+        try:
+            conn.request('POST', self.url_path, body, {'Content-Type': 'application/json'})
+            conn.getresponse().read()
+        except (OSError, http.client.HTTPException) as e:
+            self._display.warning('Failed to post event to ansible-operator: %s' % e)
+        finally:
+            conn.close()
+
+    def _task_data(self, task):
+        data = {
+            'task': task.get_name().strip(),
+            'task_action': task.action,
+            'task_path': task.get_path(),
+            'task_uuid': str(task._uuid),
+        }
+        if not task.no_log:
+            data['task_args'] = ', '.join('%s=%s' % a for a in task.args.items())
+        if task._role:
+            data['role'] = task._role.get_name()
+        return data
+
+    def _result_data(self, result, **extra):
+        data = self._task_data(result._task)
+        data['host'] = result._host.get_name()
+        if result._task.no_log:
+            data['res'] = {'censored': "the output has been hidden due to the fact that "
+                                       "'no_log: true' was specified for this result"}
+        else:
+            data['res'] = strip_internal_keys(module_response_deepcopy(result._result))
+        start = self.task_start.get(data['task_uuid'])
+        if start is not None:
+            end = datetime.datetime.now(datetime.timezone.utc)
+            data['start'] = start.replace(tzinfo=None).isoformat()
+            data['end'] = end.replace(tzinfo=None).isoformat()
+            data['duration'] = (end - start).total_seconds()
+        data.update(extra)
+        return data
+
+    def v2_playbook_on_start(self, playbook):
+        self.playbook = playbook._file_name
+        self._send('playbook_on_start', {})
+
+    def v2_playbook_on_play_start(self, play):
+        self.play = play
+        name = play.get_name().strip()
+        self._send('playbook_on_play_start', {'name': name}, 'PLAY [%s]' % name)
+
+    def v2_playbook_on_task_start(self, task, is_conditional):
+        self.task_start[str(task._uuid)] = datetime.datetime.now(datetime.timezone.utc)
+        data = self._task_data(task)
+        data['name'] = data['task']
+        data['is_conditional'] = is_conditional
+        self._send('playbook_on_task_start', data, 'TASK [%s]' % data['task'])
+
+    def v2_playbook_on_handler_task_start(self, task):
+        self.task_start[str(task._uuid)] = datetime.datetime.now(datetime.timezone.utc)
+        data = self._task_data(task)
+        data['name'] = data['task']
+        self._send('playbook_on_handler_task_start', data, 'RUNNING HANDLER [%s]' % data['task'])
+
+    def v2_runner_on_ok(self, result):
+        data = self._result_data(result)
+        state = 'changed' if result._result.get('changed', False) else 'ok'
+        self._send('runner_on_ok', data, '%s: [%s]' % (state, data['host']))
+
+    def v2_runner_on_failed(self, result, ignore_errors=False):
+        data = self._result_data(result, ignore_errors=ignore_errors)
+        msg = data['res'].get('msg', '') if isinstance(data['res'], dict) else ''
+        self._send('runner_on_failed', data, 'fatal: [%s]: FAILED! => %s' % (data['host'], msg))
+
+    def v2_runner_on_skipped(self, result):
+        data = self._result_data(result)
+        self._send('runner_on_skipped', data, 'skipping: [%s]' % data['host'])
+
+    def v2_runner_on_unreachable(self, result):
+        data = self._result_data(result)
+        self._send('runner_on_unreachable', data, 'fatal: [%s]: UNREACHABLE!' % data['host'])
+
+    def v2_runner_item_on_ok(self, result):
+        data = self._result_data(result)
+        self._send('runner_item_on_ok', data, 'ok: [%s] => (item=%s)' % (data['host'], data['res'].get('item')))
+
+    def v2_runner_item_on_failed(self, result):
+        data = self._result_data(result)
+        self._send('runner_item_on_failed', data, 'failed: [%s] => (item=%s)' % (data['host'], data['res'].get('item')))
+
+    def v2_runner_item_on_skipped(self, result):
+        data = self._result_data(result)
+        self._send('runner_item_on_skipped', data, 'skipping: [%s] => (item=%s)' % (data['host'], data['res'].get('item')))
+
+    def v2_playbook_on_stats(self, stats):
+        data = {
+            'changed': stats.changed,
+            'dark': stats.dark,
+            'failures': stats.failures,
+            'ignored': getattr(stats, 'ignored', {}),
+            'ok': stats.ok,
+            'processed': stats.processed,
+            'rescued': getattr(stats, 'rescued', {}),
+            'skipped': stats.skipped,
+        }
+        self._send('playbook_on_stats', data)
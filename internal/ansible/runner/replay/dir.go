@@ -0,0 +1,112 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner"
+)
+
+// DirRunner - a runner.Runner that replays the fixtures a Recorder saved to
+// Dir, in place of the Runner it wraps. The jobs of each custom resource
+// replay its fixtures in the order they were recorded, and the last fixture
+// for every job after that. The wrapped Runner only provides the finalizer.
+type DirRunner struct {
+	Runner runner.Runner
+	Dir    string
+
+	mu   sync.Mutex
+	runs map[types.NamespacedName]int
+}
+
+// Run - replays the next fixture recorded for u.
+func (r *DirRunner) Run(ctx context.Context, ident string, u *unstructured.Unstructured, kubeconfig, token string,
+	previousOutputs, lastSuccessfulRun map[string]interface{}) (runner.RunResult, error) {
+	fixtures, err := r.load(u)
+	if err != nil {
+		return nil, err
+	}
+	if len(fixtures) == 0 {
+		return nil, fmt.Errorf("no fixture recorded for %s in %s", fixturePrefix(u)+"<ident>.jsonl", r.Dir)
+	}
+
+	r.mu.Lock()
+	if r.runs == nil {
+		r.runs = map[types.NamespacedName]int{}
+	}
+	key := types.NamespacedName{Namespace: u.GetNamespace(), Name: u.GetName()}
+	n := r.runs[key]
+	r.runs[key]++
+	r.mu.Unlock()
+
+	replayer := &Runner{Fixtures: fixtures[min(n, len(fixtures)-1):]}
+	return replayer.Run(ctx, ident, u, kubeconfig, token, previousOutputs, lastSuccessfulRun)
+}
+
+// load reads the fixtures recorded for u, ordered by the start of their job.
+func (r *DirRunner) load(u *unstructured.Unstructured) ([]*Fixture, error) {
+	entries, err := os.ReadDir(r.Dir)
+	if err != nil {
+		return nil, err
+	}
+	prefix := fixturePrefix(u)
+	var fixtures []*Fixture
+	for _, entry := range entries {
+		ident, ok := strings.CutPrefix(entry.Name(), prefix)
+		if !ok || entry.IsDir() {
+			continue
+		}
+		// the names of other resources may start with the prefix of a
+		// cluster scoped one.
+		ident, ok = strings.CutSuffix(ident, ".jsonl")
+		if !ok || strings.Contains(ident, "_") {
+			continue
+		}
+		f, err := LoadFixture(filepath.Join(r.Dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		fixtures = append(fixtures, f)
+	}
+	sort.SliceStable(fixtures, func(i, j int) bool {
+		return startTime(fixtures[i]).Before(startTime(fixtures[j]))
+	})
+	return fixtures, nil
+}
+
+// startTime - when the job of f started, or the zero time if its outcome was
+// not recorded.
+func startTime(f *Fixture) time.Time {
+	if f.Outcome == nil {
+		return time.Time{}
+	}
+	return f.Outcome.StartTime
+}
+
+// GetFinalizer - gets the finalizer of the wrapped Runner.
+func (r *DirRunner) GetFinalizer() (string, bool) {
+	return r.Runner.GetFinalizer()
+}
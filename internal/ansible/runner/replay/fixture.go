@@ -0,0 +1,154 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package replay records the event stream and stdout of ansible-runner jobs
+// to JSONL fixtures, and replays them through a runner.Runner so that the
+// reconciler can be tested without ansible-runner installed. The operator
+// records fixtures with --ansible-record-dir and replays them in place of
+// running ansible with --ansible-replay-dir, so that operators built on it can
+// be tested, for example against envtest, without Python.
+//
+// A fixture holds one line per event, in the order they were received, and
+// final lines with the stdout and the outcome of the job:
+//
+//	{"offset":"120ms","event":{"event":"playbook_on_task_start",...}}
+//	{"offset":"1.5s","event":{"event":"playbook_on_stats",...}}
+//	{"stdout":"PLAY [localhost] ..."}
+//	{"outcome":{"rc":0,"status":"successful",...}}
+package replay
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+// Fixture - the recorded events, stdout and outcome of a single job.
+type Fixture struct {
+	Events []Event
+	Stdout string
+	// Outcome is nil if it was not recorded, in which case the job is
+	// replayed as successful.
+	Outcome *runner.Outcome
+}
+
+// Event - an event and when it was received, relative to the start of the job.
+type Event struct {
+	Offset time.Duration
+	Event  eventapi.JobEvent
+}
+
+// line is a single line of a fixture file.
+type line struct {
+	Offset  string             `json:"offset,omitempty"`
+	Event   *eventapi.JobEvent `json:"event,omitempty"`
+	Stdout  *string            `json:"stdout,omitempty"`
+	Outcome *runner.Outcome    `json:"outcome,omitempty"`
+}
+
+// LoadFixture - reads a fixture from a JSONL file.
+func LoadFixture(path string) (*Fixture, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	fixture, err := ReadFixture(f)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fixture %s: %w", path, err)
+	}
+	return fixture, nil
+}
+
+// ReadFixture - reads a fixture in JSONL format from r.
+func ReadFixture(r io.Reader) (*Fixture, error) {
+	fixture := &Fixture{}
+	scanner := bufio.NewScanner(r)
+	// Events carrying the result of a task can be large.
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+	n := 0
+	for scanner.Scan() {
+		n++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		l := line{}
+		if err := json.Unmarshal(scanner.Bytes(), &l); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		switch {
+		case l.Event != nil:
+			var offset time.Duration
+			if l.Offset != "" {
+				var err error
+				if offset, err = time.ParseDuration(l.Offset); err != nil {
+					return nil, fmt.Errorf("line %d: %w", n, err)
+				}
+			}
+			fixture.Events = append(fixture.Events, Event{Offset: offset, Event: *l.Event})
+		case l.Stdout != nil:
+			fixture.Stdout = *l.Stdout
+		case l.Outcome != nil:
+			fixture.Outcome = l.Outcome
+		default:
+			return nil, fmt.Errorf("line %d: neither an event, stdout nor outcome", n)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return fixture, nil
+}
+
+// Write - writes the fixture to w in JSONL format.
+func (f *Fixture) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	for i := range f.Events {
+		l := line{Offset: f.Events[i].Offset.String(), Event: &f.Events[i].Event}
+		if err := enc.Encode(l); err != nil {
+			return err
+		}
+	}
+	stdout := f.Stdout
+	if err := enc.Encode(line{Stdout: &stdout}); err != nil {
+		return err
+	}
+	if f.Outcome == nil {
+		return nil
+	}
+	return enc.Encode(line{Outcome: f.Outcome})
+}
+
+// Save - writes the fixture to a JSONL file at path.
+func (f *Fixture) Save(path string) (err error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, file.Close())
+	}()
+	w := bufio.NewWriter(file)
+	if err := f.Write(w); err != nil {
+		return err
+	}
+	return w.Flush()
+}
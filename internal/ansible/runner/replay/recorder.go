@@ -0,0 +1,104 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+var log = logf.Log.WithName("replay")
+
+// Recorder - a runner.Runner that records every job of the Runner it wraps to
+// a fixture in Dir. Fixtures are named <namespace>_<name>_<ident>.jsonl, or
+// <name>_<ident>.jsonl for cluster scoped resources.
+type Recorder struct {
+	Runner runner.Runner
+	Dir    string
+}
+
+// Run - runs the wrapped Runner, recording its events and stdout.
+func (r *Recorder) Run(ctx context.Context, ident string, u *unstructured.Unstructured, kubeconfig, token string,
+	previousOutputs, lastSuccessfulRun map[string]interface{}) (runner.RunResult, error) {
+	start := time.Now()
+	result, err := r.Runner.Run(ctx, ident, u, kubeconfig, token, previousOutputs, lastSuccessfulRun)
+	if err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(r.Dir, fixturePrefix(u)+ident+".jsonl")
+
+	events := make(chan eventapi.JobEvent)
+	go func() {
+		fixture := &Fixture{}
+		for event := range result.Events() {
+			fixture.Events = append(fixture.Events, Event{Offset: time.Since(start), Event: event})
+			events <- event
+		}
+		// The stdout is only available once the job has finished, so the
+		// fixture is saved before the run is seen to be done.
+		fixture.Stdout, _ = result.Stdout()
+		if outcome, err := result.Wait(); err == nil {
+			fixture.Outcome = &outcome
+		}
+		if err := os.MkdirAll(r.Dir, 0o755); err != nil {
+			log.Error(err, "Failed to create fixture directory", "dir", r.Dir)
+		} else if err := fixture.Save(path); err != nil {
+			log.Error(err, "Failed to save fixture", "path", path)
+		}
+		close(events)
+	}()
+	return &runResult{events: events, stdout: result.Stdout, wait: result.Wait}, nil
+}
+
+// fixturePrefix - the prefix of the names of the fixtures recorded for u.
+func fixturePrefix(u *unstructured.Unstructured) string {
+	if u.GetNamespace() == "" {
+		return u.GetName() + "_"
+	}
+	return fmt.Sprintf("%s_%s_", u.GetNamespace(), u.GetName())
+}
+
+// GetFinalizer - gets the finalizer of the wrapped Runner.
+func (r *Recorder) GetFinalizer() (string, bool) {
+	return r.Runner.GetFinalizer()
+}
+
+type runResult struct {
+	events <-chan eventapi.JobEvent
+	stdout func() (string, error)
+	wait   func() (runner.Outcome, error)
+}
+
+func (r *runResult) Events() <-chan eventapi.JobEvent {
+	return r.events
+}
+
+func (r *runResult) Stdout() (string, error) {
+	return r.stdout()
+}
+
+func (r *runResult) Wait() (runner.Outcome, error) {
+	return r.wait()
+}
@@ -0,0 +1,223 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/fake"
+)
+
+var jobEvents = []eventapi.JobEvent{
+	{
+		Event:     eventapi.EventRunnerOnOk,
+		Counter:   1,
+		EventData: map[string]interface{}{"task": "create deployment", "host": "localhost"},
+	},
+	{
+		Event:     eventapi.EventRunnerOnOk,
+		Counter:   2,
+		EventData: map[string]interface{}{"task": "create service", "host": "localhost"},
+	},
+	{
+		Event:   eventapi.EventPlaybookOnStats,
+		Counter: 3,
+		EventData: map[string]interface{}{
+			"ok":       map[string]interface{}{"localhost": float64(2)},
+			"failures": map[string]interface{}{},
+		},
+	},
+}
+
+func newCR() *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetName("example")
+	u.SetNamespace("default")
+	return u
+}
+
+func collect(t *testing.T, result runner.RunResult) []eventapi.JobEvent {
+	t.Helper()
+	var events []eventapi.JobEvent
+	for e := range result.Events() {
+		events = append(events, e)
+	}
+	return events
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	recorder := &Recorder{
+		Runner: &fake.Runner{JobEvents: jobEvents, Stdout: "PLAY RECAP", RC: 254, Status: runner.RunTimeout},
+		Dir:    dir,
+	}
+	result, err := recorder.Run(context.Background(), "1234", newCR(), "", "", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if recorded := collect(t, result); !reflect.DeepEqual(recorded, jobEvents) {
+		t.Fatalf("recorder changed the events:\n%v\nexpected:\n%v", recorded, jobEvents)
+	}
+
+	replayer, err := NewRunner(filepath.Join(dir, "default_example_1234.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err = replayer.Run(context.Background(), "5678", newCR(), "", "", map[string]interface{}{"revision": "v1"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayed := collect(t, result)
+	if len(replayed) != len(jobEvents) {
+		t.Fatalf("replayed %d events, expected %d", len(replayed), len(jobEvents))
+	}
+	for i := range replayed {
+		if replayed[i].Event != jobEvents[i].Event || replayed[i].Counter != jobEvents[i].Counter ||
+			!reflect.DeepEqual(replayed[i].EventData, jobEvents[i].EventData) {
+			t.Fatalf("replayed event %d:\n%v\nexpected:\n%v", i, replayed[i], jobEvents[i])
+		}
+	}
+	if stdout, err := result.Stdout(); err != nil || stdout != "PLAY RECAP" {
+		t.Fatalf("unexpected stdout %q: %v", stdout, err)
+	}
+	if outcome, err := result.Wait(); err != nil || outcome.RC != 254 || outcome.Status != runner.RunTimeout {
+		t.Fatalf("unexpected outcome %+v: %v", outcome, err)
+	}
+	expectedOutputs := []map[string]interface{}{{"revision": "v1"}}
+	if got := replayer.PreviousOutputs(); !reflect.DeepEqual(got, expectedOutputs) {
+		t.Fatalf("unexpected previous outputs %v", got)
+	}
+}
+
+func TestReplayFailures(t *testing.T) {
+	fixture := &Fixture{}
+	for _, e := range jobEvents {
+		fixture.Events = append(fixture.Events, Event{Event: e})
+	}
+	replayer := &Runner{
+		Fixtures: []*Fixture{fixture},
+		Failures: map[string]string{"create deployment": "quota exceeded"},
+	}
+	result, err := replayer.Run(context.Background(), "1234", newCR(), "", "", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := collect(t, result)
+	if len(events) != 2 {
+		t.Fatalf("expected the play to stop after the failed task, got %v", events)
+	}
+	if events[0].Event != eventapi.EventRunnerOnFailed || events[0].GetFailedPlaybookMessage() != "quota exceeded" {
+		t.Fatalf("task was not failed: %v", events[0])
+	}
+	stats := events[1].EventData
+	if ok := stats["ok"].(map[string]interface{})["localhost"]; ok != float64(1) {
+		t.Fatalf("unexpected ok count %v", ok)
+	}
+	if failures := stats["failures"].(map[string]interface{})["localhost"]; failures != float64(1) {
+		t.Fatalf("unexpected failures count %v", failures)
+	}
+	if outcome, err := result.Wait(); err != nil || outcome.RC != 2 || outcome.Status != runner.RunFailed {
+		t.Fatalf("unexpected outcome %+v: %v", outcome, err)
+	}
+	if jobEvents[0].Event != eventapi.EventRunnerOnOk {
+		t.Fatalf("fixture was modified")
+	}
+}
+
+func TestReplayTiming(t *testing.T) {
+	fixture := &Fixture{Events: []Event{
+		{Offset: 0, Event: jobEvents[0]},
+		{Offset: 100 * time.Millisecond, Event: jobEvents[2]},
+	}}
+	replayer := &Runner{Fixtures: []*Fixture{fixture}, Speed: 2}
+	start := time.Now()
+	result, err := replayer.Run(context.Background(), "1234", newCR(), "", "", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	collect(t, result)
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Fatalf("replay did not keep the recorded pace, took %s", elapsed)
+	}
+}
+
+func TestReplayError(t *testing.T) {
+	injected := errors.New("ansible-runner not found")
+	replayer := &Runner{Error: injected}
+	if _, err := replayer.Run(context.Background(), "1234", newCR(), "", "", nil, nil); !errors.Is(err, injected) {
+		t.Fatalf("expected the injected error, got %v", err)
+	}
+	if _, err := (&Runner{}).Run(context.Background(), "1234", newCR(), "", "", nil, nil); err == nil {
+		t.Fatalf("expected an error without fixtures")
+	}
+}
+
+func TestDirRunner(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Now()
+	// the names of the fixtures do not sort in the order they were recorded.
+	for ident, rc := range map[string]int{"2": 0, "1": 2} {
+		offset, _ := time.ParseDuration(ident + "m")
+		fixture := &Fixture{Outcome: &runner.Outcome{RC: rc, StartTime: start.Add(-offset)}}
+		if err := fixture.Save(filepath.Join(dir, "default_example_"+ident+".jsonl")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// a fixture of a cluster scoped resource named default.
+	if err := (&Fixture{Outcome: &runner.Outcome{RC: 3}}).Save(filepath.Join(dir, "default_9.jsonl")); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &DirRunner{Runner: &fake.Runner{Finalizer: "finalizer"}, Dir: dir}
+	for i, rc := range []int{0, 2, 2} {
+		result, err := r.Run(context.Background(), "1234", newCR(), "", "", nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		collect(t, result)
+		if outcome, err := result.Wait(); err != nil || outcome.RC != rc {
+			t.Fatalf("run %d: unexpected outcome %+v, expected rc %d: %v", i, outcome, rc, err)
+		}
+	}
+	if finalizer, ok := r.GetFinalizer(); !ok || finalizer != "finalizer" {
+		t.Fatalf("unexpected finalizer %q", finalizer)
+	}
+
+	clusterScoped := &unstructured.Unstructured{}
+	clusterScoped.SetName("default")
+	result, err := r.Run(context.Background(), "1234", clusterScoped, "", "", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	collect(t, result)
+	if outcome, err := result.Wait(); err != nil || outcome.RC != 3 {
+		t.Fatalf("unexpected outcome %+v for the cluster scoped resource: %v", outcome, err)
+	}
+
+	other := newCR()
+	other.SetName("other")
+	if _, err := r.Run(context.Background(), "1234", other, "", "", nil, nil); err == nil {
+		t.Fatalf("expected an error for a resource without fixtures")
+	}
+}
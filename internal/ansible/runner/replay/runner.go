@@ -0,0 +1,199 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+// Runner - a runner.Runner that replays fixtures. Each run replays the next
+// fixture, and the last fixture is replayed for every run after that.
+type Runner struct {
+	Fixtures []*Fixture
+	// Speed is the pace of the replay relative to the recording: 1 replays
+	// events at the pace they were recorded, 2 twice as fast. Zero replays
+	// them without delay.
+	Speed float64
+	// Failures makes the named tasks fail with the given message. As with a
+	// real run, no task runs after a failed one, and the run fails with rc 2.
+	Failures map[string]string
+	// Error is returned by Run, if set.
+	Error error
+	// Finalizer is returned by GetFinalizer.
+	Finalizer string
+
+	mu   sync.Mutex
+	runs int
+	// previousOutputs records the previous outputs given to each run.
+	previousOutputs []map[string]interface{}
+}
+
+// NewRunner - returns a Runner replaying the fixtures at the given paths.
+func NewRunner(paths ...string) (*Runner, error) {
+	r := &Runner{}
+	for _, path := range paths {
+		f, err := LoadFixture(path)
+		if err != nil {
+			return nil, err
+		}
+		r.Fixtures = append(r.Fixtures, f)
+	}
+	return r, nil
+}
+
+// Run - replays the next fixture.
+func (r *Runner) Run(_ context.Context, _ string, _ *unstructured.Unstructured, _, _ string,
+	previousOutputs, _ map[string]interface{}) (runner.RunResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.previousOutputs = append(r.previousOutputs, previousOutputs)
+	if r.Error != nil {
+		return nil, r.Error
+	}
+	if len(r.Fixtures) == 0 {
+		return nil, errors.New("no fixture to replay")
+	}
+	fixture := r.Fixtures[min(r.runs, len(r.Fixtures)-1)]
+	r.runs++
+
+	events := make(chan eventapi.JobEvent)
+	done := make(chan struct{})
+	outcome := runner.Outcome{Status: runner.RunSuccessful}
+	if fixture.Outcome != nil {
+		outcome = *fixture.Outcome
+	}
+	go func() {
+		defer close(done)
+		outcome.StartTime = time.Now()
+		if r.replay(fixture, events) {
+			outcome.RC, outcome.Status = 2, runner.RunFailed
+		}
+		outcome.EndTime = time.Now()
+	}()
+	return &runResult{
+		events: events,
+		stdout: func() (string, error) {
+			if fixture.Stdout == "" {
+				return "", fmt.Errorf("unable to find standard out")
+			}
+			return fixture.Stdout, nil
+		},
+		wait: func() (runner.Outcome, error) {
+			<-done
+			return outcome, nil
+		},
+	}, nil
+}
+
+// replay sends the events of the fixture, returning true if a task was made
+// to fail.
+func (r *Runner) replay(fixture *Fixture, events chan<- eventapi.JobEvent) bool {
+	defer close(events)
+	start := time.Now()
+	failed := map[string]int{}
+	for _, e := range fixture.Events {
+		if r.Speed > 0 {
+			time.Sleep(time.Until(start.Add(time.Duration(float64(e.Offset) / r.Speed))))
+		}
+		event := e.Event
+		switch {
+		case event.Event == eventapi.EventPlaybookOnStats:
+			event = countFailures(event, failed)
+		case len(failed) > 0:
+			// the play stops at the first failed task.
+			continue
+		case event.Event == eventapi.EventRunnerOnOk:
+			task, _ := event.EventData["task"].(string)
+			if msg, ok := r.Failures[task]; ok {
+				event = fail(event, msg)
+				host, _ := event.EventData["host"].(string)
+				failed[host]++
+			}
+		}
+		events <- event
+	}
+	return len(failed) > 0
+}
+
+// Runs - returns the number of runs so far.
+func (r *Runner) Runs() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.runs
+}
+
+// PreviousOutputs - returns the previous outputs given to each run so far.
+func (r *Runner) PreviousOutputs() []map[string]interface{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]map[string]interface{}{}, r.previousOutputs...)
+}
+
+// GetFinalizer - gets the finalizer.
+func (r *Runner) GetFinalizer() (string, bool) {
+	return r.Finalizer, r.Finalizer != ""
+}
+
+// fail turns an ok event into a failed one.
+func fail(event eventapi.JobEvent, msg string) eventapi.JobEvent {
+	data := make(map[string]interface{}, len(event.EventData))
+	for k, v := range event.EventData {
+		data[k] = v
+	}
+	data["res"] = map[string]interface{}{"failed": true, "msg": msg}
+	event.Event = eventapi.EventRunnerOnFailed
+	event.EventData = data
+	return event
+}
+
+// countFailures moves the ok tasks that were made to fail to the failures of
+// a playbook_on_stats event.
+func countFailures(event eventapi.JobEvent, failed map[string]int) eventapi.JobEvent {
+	if len(failed) == 0 {
+		return event
+	}
+	data := make(map[string]interface{}, len(event.EventData))
+	for k, v := range event.EventData {
+		data[k] = v
+	}
+	for _, key := range []string{"ok", "failures"} {
+		counts := map[string]interface{}{}
+		if m, ok := data[key].(map[string]interface{}); ok {
+			for host, n := range m {
+				counts[host] = n
+			}
+		}
+		for host, n := range failed {
+			c, _ := counts[host].(float64)
+			if key == "ok" {
+				counts[host] = max(c-float64(n), 0)
+			} else {
+				counts[host] = c + float64(n)
+			}
+		}
+		data[key] = counts
+	}
+	event.EventData = data
+	return event
+}
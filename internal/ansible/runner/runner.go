@@ -15,28 +15,74 @@
 package runner
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"net/http"
+	"maps"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/metrics"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/paramconv"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/redact"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/internal/inputdir"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/tracing"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/watches"
+	sdkVersion "github.com/operator-framework/ansible-operator-plugins/internal/version"
 )
 
 var log = logf.Log.WithName("runner")
 
+var (
+	// eventServer receives the events of every run, once started.
+	eventServer      *eventapi.Server
+	eventServerMutex sync.Mutex
+)
+
+// sharedEventServer returns the event API shared by every run, starting it on
+// first use.
+func sharedEventServer() (*eventapi.Server, error) {
+	eventServerMutex.Lock()
+	defer eventServerMutex.Unlock()
+	if eventServer != nil {
+		return eventServer, nil
+	}
+	server, err := eventapi.NewServer(fmt.Sprintf("/tmp/ansibleoperator-%d", os.Getpid()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to start the event API: %w", err)
+	}
+	eventServer = server
+	return eventServer, nil
+}
+
+// CloseEventServer stops the event API shared by every run, if it was
+// started, and removes its socket. It is called on shutdown, once no run is
+// left.
+func CloseEventServer() error {
+	eventServerMutex.Lock()
+	defer eventServerMutex.Unlock()
+	if eventServer == nil {
+		return nil
+	}
+	err := eventServer.Close()
+	eventServer = nil
+	return err
+}
+
 const (
 	// MaxRunnerArtifactsAnnotation - annotation used by a user to specify the max artifacts to keep
 	// in the runner directory. This will override the value provided by the watches file for a
@@ -49,13 +95,21 @@ const (
 	// Example usage "ansible.sdk.operatorframework.io/verbosity: 5"
 	AnsibleVerbosityAnnotation = "ansible.sdk.operatorframework.io/verbosity"
 
-	ansibleRunnerBin = "ansible-runner"
+	// RunTokenEnvVar - environment variable holding the token issued to the
+	// run, which it authenticates to the proxy and the apiserver with.
+	RunTokenEnvVar = "ANSIBLE_OPERATOR_RUN_TOKEN"
 )
 
 // Runner - a runnable that should take the parameters and name and namespace
-// and run the correct code.
+// and run the correct code. The outputs set by the previous run, if they are
+// kept, are passed to the playbook or role as ansible_operator_previous_outputs.
+// The ansibleResult of the last successful run, if any, is passed as
+// ansible_operator_meta.last_successful_run. The token issued to the run, if
+// not empty, is set in its environment as ANSIBLE_OPERATOR_RUN_TOKEN and masked
+// in everything the run exposes. The run is traced as part of the trace in ctx.
 type Runner interface {
-	Run(string, *unstructured.Unstructured, string) (RunResult, error)
+	Run(ctx context.Context, ident string, u *unstructured.Unstructured, kubeconfig, token string,
+		previousOutputs, lastSuccessfulRun map[string]interface{}) (RunResult, error)
 	GetFinalizer() (string, bool)
 }
 
@@ -117,8 +171,10 @@ func roleCmdFunc(path string) cmdFuncType {
 	}
 }
 
-// New - creates a Runner from a Watch struct
-func New(watch watches.Watch, runnerArgs string) (Runner, error) {
+// New - creates a Runner from a Watch struct. Playbooks and roles are run
+// with the given executor, ansible-runner if empty, with env set in the
+// environment of every run.
+func New(watch watches.Watch, runnerArgs string, executor Executor, env map[string]string) (Runner, error) {
 	var path string
 	var cmdFunc, finalizerCmdFunc cmdFuncType
 
@@ -128,6 +184,23 @@ func New(watch watches.Watch, runnerArgs string) (Runner, error) {
 		return nil, err
 	}
 
+	playbookCmdFunc, roleCmdFunc := playbookCmdFunc, roleCmdFunc
+	switch executor {
+	case "":
+		executor = AnsibleRunnerExecutor
+	case AnsibleRunnerExecutor:
+	case AnsiblePlaybookExecutor:
+		args, err := splitAnsibleArgs(runnerArgs)
+		if err != nil {
+			return nil, err
+		}
+		playbookCmdFunc = func(path string) cmdFuncType { return nativePlaybookCmdFunc(path, args) }
+		roleCmdFunc = func(path string) cmdFuncType { return nativeRoleCmdFunc(path, args) }
+	default:
+		return nil, fmt.Errorf("unknown executor %q, must be %q or %q", executor, AnsibleRunnerExecutor,
+			AnsiblePlaybookExecutor)
+	}
+
 	switch {
 	case watch.Playbook != "":
 		path = watch.Playbook
@@ -137,6 +210,12 @@ func New(watch watches.Watch, runnerArgs string) (Runner, error) {
 		cmdFunc = roleCmdFunc(path)
 	}
 
+	redactor, err := redact.New(watch.RedactPaths)
+	if err != nil {
+		log.Error(err, "Failed to create redactor")
+		return nil, err
+	}
+
 	// handle finalizer
 	switch {
 	case watch.Finalizer == nil:
@@ -160,7 +239,11 @@ func New(watch watches.Watch, runnerArgs string) (Runner, error) {
 		ansibleVerbosity:    watch.AnsibleVerbosity,
 		ansibleArgs:         runnerArgs,
 		snakeCaseParameters: watch.SnakeCaseParameters,
+		converter:           paramconv.NewConverter(watch.ParameterConversion),
+		executor:            executor,
 		markUnsafe:          watch.MarkUnsafe,
+		redactor:            redactor,
+		env:                 env,
 	}, nil
 }
 
@@ -175,12 +258,33 @@ type runner struct {
 	maxRunnerArtifacts  int
 	ansibleVerbosity    int
 	snakeCaseParameters bool
+	converter           *paramconv.Converter
+	executor            Executor
 	markUnsafe          bool
 	ansibleArgs         string
+	redactor            *redact.Redactor
+	env                 map[string]string
 }
 
-func (r *runner) Run(ident string, u *unstructured.Unstructured, kubeconfig string) (RunResult, error) {
-	if _, err := exec.LookPath(ansibleRunnerBin); err != nil {
+func (r *runner) Run(ctx context.Context, ident string, u *unstructured.Unstructured, kubeconfig, token string,
+	previousOutputs, lastSuccessfulRun map[string]interface{}) (_ RunResult, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "Runner.Run", trace.WithAttributes(
+		attribute.String("ansible.job", ident),
+		attribute.String("ansible.executor", string(r.executor)),
+		attribute.String("k8s.gvk", r.GVK.String()),
+		attribute.String("k8s.namespace", u.GetNamespace()),
+		attribute.String("k8s.name", u.GetName()),
+		attribute.Bool("ansible.finalizer_run", r.isFinalizerRun(u)),
+	))
+	// once started, the span of the run ends with the run.
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			span.End()
+		}
+	}()
+
+	if _, err := exec.LookPath(string(r.executor)); err != nil {
 		return nil, err
 	}
 
@@ -196,31 +300,41 @@ func (r *runner) Run(ident string, u *unstructured.Unstructured, kubeconfig stri
 		"namespace", u.GetNamespace(),
 	)
 
-	// start the event receiver. We'll check errChan for an error after
-	// ansible-runner exits.
-	errChan := make(chan error, 1)
-	receiver, err := eventapi.New(ident, errChan)
+	// register the run with the event API, to receive its events.
+	server, err := sharedEventServer()
+	if err != nil {
+		return nil, err
+	}
+	receiver, err := server.Register(ident)
 	if err != nil {
 		return nil, err
 	}
+	masker := r.redactor.ForObject(u)
 	inputDir := inputdir.InputDir{
 		Path: filepath.Join("/tmp/ansible-operator/runner/", r.GVK.Group, r.GVK.Version, r.GVK.Kind,
 			u.GetNamespace(), u.GetName()),
-		Parameters: r.makeParameters(u),
-		EnvVars: map[string]string{
-			"K8S_AUTH_KUBECONFIG": kubeconfig,
-			"KUBECONFIG":          kubeconfig,
-		},
+		Parameters: r.makeParameters(ident, u, previousOutputs, lastSuccessfulRun),
+		EnvVars:    maps.Clone(r.env),
 		Settings: map[string]string{
 			"runner_http_url":  receiver.SocketPath,
 			"runner_http_path": receiver.URLPath,
 		},
 		CmdLine: r.ansibleArgs,
 	}
+	if inputDir.EnvVars == nil {
+		inputDir.EnvVars = map[string]string{}
+	}
+	inputDir.EnvVars["K8S_AUTH_KUBECONFIG"] = kubeconfig
+	inputDir.EnvVars["KUBECONFIG"] = kubeconfig
+	if token != "" {
+		inputDir.EnvVars[RunTokenEnvVar] = token
+		masker.Add(token)
+	}
 	// If Path is a dir, assume it is a role path. Otherwise assume it's a
 	// playbook path
 	fi, err := os.Lstat(r.Path)
 	if err != nil {
+		receiver.Close()
 		return nil, err
 	}
 	if !fi.IsDir() {
@@ -228,8 +342,15 @@ func (r *runner) Run(ident string, u *unstructured.Unstructured, kubeconfig stri
 	}
 	err = inputDir.Write()
 	if err != nil {
+		receiver.Close()
 		return nil, err
 	}
+	if r.executor == AnsiblePlaybookExecutor {
+		if err := writeNativeFiles(inputDir.Path); err != nil {
+			receiver.Close()
+			return nil, err
+		}
+	}
 	maxArtifacts := r.maxRunnerArtifacts
 	if ma, ok := u.GetAnnotations()[MaxRunnerArtifactsAnnotation]; ok {
 		i, err := strconv.Atoi(ma)
@@ -250,7 +371,16 @@ func (r *runner) Run(ident string, u *unstructured.Unstructured, kubeconfig stri
 		}
 	}
 
+	result := &runResult{
+		inputDir: &inputDir,
+		ident:    ident,
+		masker:   masker,
+		done:     make(chan struct{}),
+	}
+
 	go func() {
+		defer close(result.done)
+		defer metrics.RunStarted(r.GVK.String(), r.isFinalizerRun(u))()
 		var dc *exec.Cmd
 		if r.isFinalizerRun(u) {
 			logger.V(1).Info("Resource is marked for deletion, running finalizer",
@@ -261,22 +391,55 @@ func (r *runner) Run(ident string, u *unstructured.Unstructured, kubeconfig stri
 		}
 		// Append current environment since setting dc.Env to anything other than nil overwrites current env
 		dc.Env = append(dc.Env, os.Environ()...)
-		dc.Env = append(dc.Env, fmt.Sprintf("K8S_AUTH_KUBECONFIG=%s", kubeconfig),
-			fmt.Sprintf("KUBECONFIG=%s", kubeconfig))
+		// ansible-playbook does not read env/envvars, so the environment of
+		// the run is set on the process for both executors.
+		for _, name := range slices.Sorted(maps.Keys(inputDir.EnvVars)) {
+			dc.Env = append(dc.Env, fmt.Sprintf("%s=%s", name, inputDir.EnvVars[name]))
+		}
+		if r.executor == AnsiblePlaybookExecutor {
+			dc.Env = append(dc.Env, nativeEnv(inputDir.Path, receiver)...)
+		}
 
+		result.outcome.StartTime = time.Now()
 		output, err := dc.CombinedOutput()
+		result.outcome.EndTime = time.Now()
+		result.outcome.RC = -1
+		if dc.ProcessState != nil {
+			result.outcome.RC = dc.ProcessState.ExitCode()
+		}
+		result.outcome.ArtifactPath = filepath.Join(inputDir.Path, "artifacts", ident)
+		var exitErr *exec.ExitError
+		if err != nil && !errors.As(err, &exitErr) {
+			result.err = err
+		}
 		if err != nil {
-			logger.Error(err, string(output))
+			logger.Error(err, masker.String(string(output)))
 		} else {
-			logger.Info("Ansible-runner exited successfully")
+			logger.Info(fmt.Sprintf("%s exited successfully", r.executor))
+		}
+		if r.executor == AnsiblePlaybookExecutor {
+			if err := writeNativeArtifacts(inputDir.Path, ident, output, maxArtifacts); err != nil {
+				logger.Error(err, "Failed to write ansible-playbook artifacts")
+			}
+		}
+
+		// mask the artifacts before the events channel is closed, so that
+		// they are already redacted by the time the run is considered done.
+		if err := inputDir.Redact(ident, masker); err != nil {
+			logger.Error(err, "Failed to redact ansible-runner artifacts")
 		}
 
 		receiver.Close()
-		err = <-errChan
-		// http.Server returns this in the case of being closed cleanly
-		if err != nil && err != http.ErrServerClosed {
-			logger.Error(err, "Error from event API")
+		result.outcome.Status = runStatus(receiver.Status(), dc.ProcessState)
+		span.SetAttributes(
+			attribute.Int("ansible.rc", result.outcome.RC),
+			attribute.String("ansible.status", string(result.outcome.Status)),
+		)
+		if !result.outcome.Successful() {
+			span.SetStatus(codes.Error, fmt.Sprintf("ansible exited with rc %d and status %s",
+				result.outcome.RC, result.outcome.Status))
 		}
+		span.End()
 
 		// link the current run to the `latest` directory under artifacts
 		currentRun := filepath.Join(inputDir.Path, "artifacts", ident)
@@ -296,11 +459,125 @@ func (r *runner) Run(ident string, u *unstructured.Unstructured, kubeconfig stri
 		}
 	}()
 
-	return &runResult{
-		events:   receiver.Events,
-		inputDir: &inputDir,
-		ident:    ident,
-	}, nil
+	events := make(chan eventapi.JobEvent, cap(receiver.Events))
+	go func() {
+		for event := range receiver.Events {
+			observeTask(r.GVK.String(), event)
+			traceTask(ctx, event)
+			events <- masker.Event(event)
+		}
+		close(events)
+	}()
+
+	result.events = events
+	return result, nil
+}
+
+// taskResult returns the result of the task of an event, if it is the result
+// of a task.
+func taskResult(event eventapi.JobEvent) (string, bool) {
+	var result string
+	switch event.Event {
+	case eventapi.EventRunnerOnOk:
+		result = "ok"
+		if res, ok := event.EventData["res"].(map[string]interface{}); ok && res["changed"] == true {
+			result = "changed"
+		}
+	case eventapi.EventRunnerOnFailed:
+		result = "failed"
+		if event.IgnoreError() {
+			result = "ignored"
+		}
+	case eventapi.EventRunnerOnSkipped:
+		result = "skipped"
+	case eventapi.EventRunnerOnUnreachable:
+		result = "unreachable"
+	default:
+		return "", false
+	}
+	return result, true
+}
+
+// observeTask updates the task metrics with the result of a task.
+func observeTask(gvk string, event eventapi.JobEvent) {
+	result, ok := taskResult(event)
+	if !ok {
+		return
+	}
+	role, _ := event.EventData["role"].(string)
+	task, _ := event.EventData["task"].(string)
+	seconds, hasDuration := event.EventData["duration"].(float64)
+	metrics.TaskFinished(gvk, role, task, result, time.Duration(seconds*float64(time.Second)), hasDuration)
+}
+
+// traceTask records a span for the task of an event, if it is the result of a
+// task, spanning from when the task started to when it ended.
+func traceTask(ctx context.Context, event eventapi.JobEvent) {
+	result, ok := taskResult(event)
+	if !ok {
+		return
+	}
+	start, end := taskTimes(event)
+	task, _ := event.EventData["task"].(string)
+	role, _ := event.EventData["role"].(string)
+	action, _ := event.EventData["task_action"].(string)
+	host, _ := event.EventData["host"].(string)
+	_, span := tracing.Tracer().Start(ctx, task, trace.WithTimestamp(start), trace.WithAttributes(
+		attribute.String("ansible.task", task),
+		attribute.String("ansible.role", role),
+		attribute.String("ansible.action", action),
+		attribute.String("ansible.host", host),
+		attribute.String("ansible.result", result),
+	))
+	if result == "failed" || result == "unreachable" {
+		span.SetStatus(codes.Error, result)
+	}
+	span.End(trace.WithTimestamp(end))
+}
+
+// taskTimes returns when the task of a result event started and ended. The
+// callback plugin sets both in UTC; the event is assumed to have been created
+// when the task ended otherwise.
+func taskTimes(event eventapi.JobEvent) (time.Time, time.Time) {
+	end := event.Created.Time
+	if end.IsZero() {
+		end = time.Now()
+	}
+	const layout = "2006-01-02T15:04:05.999999999"
+	if s, ok := event.EventData["end"].(string); ok {
+		if t, err := time.Parse(layout, s); err == nil {
+			end = t
+		}
+	}
+	start := end
+	if s, ok := event.EventData["start"].(string); ok {
+		if t, err := time.Parse(layout, s); err == nil {
+			start = t
+		}
+	} else if seconds, ok := event.EventData["duration"].(float64); ok {
+		start = end.Add(-time.Duration(seconds * float64(time.Second)))
+	}
+	return start, end
+}
+
+// runStatus returns the status reported by ansible-runner, or the status
+// matching how the executor exited if none was reported.
+func runStatus(reported string, state *os.ProcessState) RunStatus {
+	switch status := RunStatus(reported); status {
+	case RunSuccessful, RunFailed, RunTimeout, RunCanceled:
+		return status
+	}
+	switch {
+	case state == nil:
+		return RunFailed
+	case state.Success():
+		return RunSuccessful
+	case state.ExitCode() == -1:
+		// the executor was killed by a signal.
+		return RunCanceled
+	default:
+		return RunFailed
+	}
 }
 
 func (r *runner) isFinalizerRun(u *unstructured.Unstructured) bool {
@@ -322,7 +599,17 @@ func (r *runner) isFinalizerRun(u *unstructured.Unstructured) bool {
 //	{ "ansible_operator_meta": {
 //	     "name": <object_name>,
 //	     "namespace": <object_namespace>,
+//	     "uid": <object_uid>,
+//	     "generation": <object_generation>,
+//	     "labels": <object_labels>,
+//	     "annotations": <object_annotations>,
+//	     "gvk": { "group": <group>, "version": <version>, "kind": <kind> },
+//	     "finalizer_run": <true if the finalizer is being run>,
+//	     "job_ident": <ident of this run>,
+//	     "operator_version": <ansible-operator version>,
+//	     "last_successful_run": <ansibleResult of the last successful run, if any>,
 //	  },
+//	  "ansible_operator_previous_outputs": <outputs of the previous run, if kept>,
 //	  <cr_spec_fields_as_snake_case>,
 //	  <watch vars>,
 //	  <finalizer vars>,
@@ -333,7 +620,8 @@ func (r *runner) isFinalizerRun(u *unstructured.Unstructured) bool {
 //	      <cr_object.spec> as is
 //	  }
 //	}
-func (r *runner) makeParameters(u *unstructured.Unstructured) map[string]interface{} {
+func (r *runner) makeParameters(ident string, u *unstructured.Unstructured,
+	previousOutputs, lastSuccessfulRun map[string]interface{}) map[string]interface{} {
 	s := u.Object["spec"]
 	spec, ok := s.(map[string]interface{})
 	if !ok {
@@ -345,7 +633,7 @@ func (r *runner) makeParameters(u *unstructured.Unstructured) map[string]interfa
 	parameters := map[string]interface{}{}
 
 	if r.snakeCaseParameters {
-		parameters = paramconv.MapToSnake(spec)
+		parameters = r.converter.MapToSnake(spec)
 	} else {
 		for k, v := range spec {
 			parameters[k] = v
@@ -358,7 +646,13 @@ func (r *runner) makeParameters(u *unstructured.Unstructured) map[string]interfa
 		}
 	}
 
-	parameters["ansible_operator_meta"] = map[string]string{"namespace": u.GetNamespace(), "name": u.GetName()}
+	parameters["ansible_operator_meta"] = r.makeMeta(ident, u, lastSuccessfulRun)
+	if previousOutputs != nil {
+		parameters["ansible_operator_previous_outputs"] = previousOutputs
+		if r.markUnsafe {
+			parameters["ansible_operator_previous_outputs"] = markUnsafe(previousOutputs)
+		}
+	}
 
 	objKey := escapeAnsibleKey(fmt.Sprintf("_%v_%v", r.GVK.Group, strings.ToLower(r.GVK.Kind)))
 	parameters[objKey] = u.Object
@@ -381,6 +675,52 @@ func (r *runner) makeParameters(u *unstructured.Unstructured) map[string]interfa
 	return parameters
 }
 
+// makeMeta - creates the ansible_operator_meta parameter, which describes the
+// CR being reconciled and the run itself independently of the CR's group.
+func (r *runner) makeMeta(ident string, u *unstructured.Unstructured,
+	lastSuccessfulRun map[string]interface{}) map[string]interface{} {
+	var labels, annotations interface{} = stringMap(u.GetLabels()), stringMap(u.GetAnnotations())
+	if r.markUnsafe {
+		// labels and annotations are user provided, like the spec.
+		labels, annotations = markUnsafe(labels), markUnsafe(annotations)
+	}
+
+	operatorVersion := sdkVersion.GitVersion
+	if operatorVersion == "unknown" {
+		operatorVersion = sdkVersion.Version
+	}
+
+	meta := map[string]interface{}{
+		"name":        u.GetName(),
+		"namespace":   u.GetNamespace(),
+		"uid":         string(u.GetUID()),
+		"generation":  u.GetGeneration(),
+		"labels":      labels,
+		"annotations": annotations,
+		"gvk": map[string]interface{}{
+			"group":   r.GVK.Group,
+			"version": r.GVK.Version,
+			"kind":    r.GVK.Kind,
+		},
+		"finalizer_run":    r.isFinalizerRun(u),
+		"job_ident":        ident,
+		"operator_version": operatorVersion,
+	}
+	if lastSuccessfulRun != nil {
+		meta["last_successful_run"] = lastSuccessfulRun
+	}
+	return meta
+}
+
+// stringMap converts a map[string]string into a map that can be marked unsafe.
+func stringMap(in map[string]string) map[string]interface{} {
+	out := make(map[string]interface{}, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
+
 // markUnsafe recursively checks for string values and marks them unsafe.
 // for eg:
 //
@@ -435,6 +775,38 @@ type RunResult interface {
 	Stdout() (string, error)
 	// Events returns the events from ansible-runner if it is available, else an error.
 	Events() <-chan eventapi.JobEvent
+	// Wait blocks until the run has finished and returns its outcome. The
+	// error is set if ansible could not be run at all.
+	Wait() (Outcome, error)
+}
+
+// RunStatus - the status a run finished with.
+type RunStatus string
+
+const (
+	// RunSuccessful - every task of the run succeeded.
+	RunSuccessful RunStatus = "successful"
+	// RunFailed - a task failed, or ansible exited with an error.
+	RunFailed RunStatus = "failed"
+	// RunTimeout - the run was stopped after running for too long.
+	RunTimeout RunStatus = "timeout"
+	// RunCanceled - the run was stopped before it finished.
+	RunCanceled RunStatus = "canceled"
+)
+
+// Outcome - how a run of ansible finished.
+type Outcome struct {
+	// RC is the exit code of ansible, or -1 if it did not exit on its own.
+	RC           int       `json:"rc"`
+	Status       RunStatus `json:"status"`
+	StartTime    time.Time `json:"start_time"`
+	EndTime      time.Time `json:"end_time"`
+	ArtifactPath string    `json:"artifact_path,omitempty"`
+}
+
+// Successful - returns true if the run finished successfully.
+func (o Outcome) Successful() bool {
+	return o.Status == RunSuccessful
 }
 
 // RunResult facilitates access to information about a run of ansible.
@@ -445,14 +817,27 @@ type runResult struct {
 
 	ident    string
 	inputDir *inputdir.InputDir
+	masker   *redact.Masker
+
+	// done is closed once outcome and err are set.
+	done    chan struct{}
+	outcome Outcome
+	err     error
 }
 
 // Stdout returns the stdout from ansible-runner if it is available, else an error.
 func (r *runResult) Stdout() (string, error) {
-	return r.inputDir.Stdout(r.ident)
+	stdout, err := r.inputDir.Stdout(r.ident)
+	return r.masker.String(stdout), err
 }
 
 // Events returns the events from ansible-runner if it is available, else an error.
 func (r *runResult) Events() <-chan eventapi.JobEvent {
 	return r.events
 }
+
+// Wait blocks until the run has finished and returns its outcome.
+func (r *runResult) Wait() (Outcome, error) {
+	<-r.done
+	return r.outcome, r.err
+}
@@ -20,10 +20,12 @@ import (
 	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/watches"
 )
 
@@ -159,7 +161,7 @@ func TestNew(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			testWatch := watches.New(tc.gvk, tc.role, tc.playbook, tc.vars, tc.finalizer)
 
-			testRunner, err := New(*testWatch, "")
+			testRunner, err := New(*testWatch, "", AnsibleRunnerExecutor, nil)
 			if err != nil {
 				t.Fatalf("Error occurred unexpectedly: %v", err)
 			}
@@ -181,7 +183,7 @@ func TestNew(t *testing.T) {
 
 			// check that the group + kind are properly formatted into a parameter
 			if tc.desiredObjectKey != "" {
-				parameters := testRunnerStruct.makeParameters(&unstructured.Unstructured{})
+				parameters := testRunnerStruct.makeParameters("test", &unstructured.Unstructured{}, nil, nil)
 				if _, ok := parameters[tc.desiredObjectKey]; !ok {
 					t.Fatalf("Did not find expected objKey %v in parameters %+v", tc.desiredObjectKey, parameters)
 				}
@@ -244,6 +246,39 @@ func TestAnsibleVerbosityString(t *testing.T) {
 	}
 }
 
+func TestRunStatus(t *testing.T) {
+	processState := func(script string) *os.ProcessState {
+		cmd := exec.Command("sh", "-c", script)
+		_ = cmd.Run()
+		return cmd.ProcessState
+	}
+	succeeded := processState("exit 0")
+	failed := processState("exit 2")
+	killed := processState("kill -9 $$")
+
+	testCases := []struct {
+		name     string
+		reported string
+		state    *os.ProcessState
+		expected RunStatus
+	}{
+		{name: "reported status", reported: "timeout", state: failed, expected: RunTimeout},
+		{name: "reported status wins", reported: "failed", state: succeeded, expected: RunFailed},
+		{name: "intermediate status", reported: "running", state: succeeded, expected: RunSuccessful},
+		{name: "exit 0", state: succeeded, expected: RunSuccessful},
+		{name: "exit 2", state: failed, expected: RunFailed},
+		{name: "killed", state: killed, expected: RunCanceled},
+		{name: "not started", expected: RunFailed},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := runStatus(tc.reported, tc.state); got != tc.expected {
+				t.Fatalf("Unexpected status %q, expected %q", got, tc.expected)
+			}
+		})
+	}
+}
+
 func TestMakeParameters(t *testing.T) {
 	var (
 		inputSpec = "testKey"
@@ -357,7 +392,7 @@ func TestMakeParameters(t *testing.T) {
 		testRunner := runner{
 			markUnsafe: true,
 		}
-		parameters := testRunner.makeParameters(&tc.inputParams)
+		parameters := testRunner.makeParameters("test", &tc.inputParams, nil, nil)
 
 		val, ok := parameters[inputSpec]
 		if !ok {
@@ -370,3 +405,194 @@ func TestMakeParameters(t *testing.T) {
 		}
 	}
 }
+
+func TestMakeMeta(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "app.example.com", Version: "v1alpha1", Kind: "Example"}
+	lastResult := map[string]interface{}{
+		"ok":         int64(3),
+		"changed":    int64(1),
+		"skipped":    int64(0),
+		"failures":   int64(0),
+		"completion": "2026-01-02T15:04:05.000000+00:00",
+	}
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "app.example.com/v1alpha1",
+		"kind":       "Example",
+		"metadata": map[string]interface{}{
+			"name":        "example",
+			"namespace":   "default",
+			"uid":         "3b8e1c0a",
+			"generation":  int64(4),
+			"labels":      map[string]interface{}{"app": "example"},
+			"annotations": map[string]interface{}{"note": "{{ lookup('pipe', 'id') }}"},
+		},
+	}}
+
+	testCases := []struct {
+		name       string
+		markUnsafe bool
+		expected   map[string]interface{}
+	}{
+		{
+			name: "safe labels and annotations",
+			expected: map[string]interface{}{
+				"name":        "example",
+				"namespace":   "default",
+				"uid":         "3b8e1c0a",
+				"generation":  int64(4),
+				"labels":      map[string]interface{}{"app": "example"},
+				"annotations": map[string]interface{}{"note": "{{ lookup('pipe', 'id') }}"},
+				"gvk": map[string]interface{}{
+					"group":   "app.example.com",
+					"version": "v1alpha1",
+					"kind":    "Example",
+				},
+				"finalizer_run":       false,
+				"job_ident":           "1234",
+				"operator_version":    "unknown",
+				"last_successful_run": lastResult,
+			},
+		},
+		{
+			name:       "unsafe labels and annotations",
+			markUnsafe: true,
+			expected: map[string]interface{}{
+				"name":       "example",
+				"namespace":  "default",
+				"uid":        "3b8e1c0a",
+				"generation": int64(4),
+				"labels": map[string]interface{}{
+					"app": map[string]interface{}{"__ansible_unsafe": "example"},
+				},
+				"annotations": map[string]interface{}{
+					"note": map[string]interface{}{"__ansible_unsafe": "{{ lookup('pipe', 'id') }}"},
+				},
+				"gvk": map[string]interface{}{
+					"group":   "app.example.com",
+					"version": "v1alpha1",
+					"kind":    "Example",
+				},
+				"finalizer_run":       false,
+				"job_ident":           "1234",
+				"operator_version":    "unknown",
+				"last_successful_run": lastResult,
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			testRunner := runner{GVK: gvk, markUnsafe: tc.markUnsafe}
+			parameters := testRunner.makeParameters("1234", u, nil, lastResult)
+			if !reflect.DeepEqual(parameters["ansible_operator_meta"], tc.expected) {
+				t.Fatalf("Unexpected ansible_operator_meta:\n%#v\nexpected:\n%#v",
+					parameters["ansible_operator_meta"], tc.expected)
+			}
+		})
+	}
+}
+
+func TestMakeParametersPreviousOutputs(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "app.example.com", Version: "v1alpha1", Kind: "Example"}
+	u := &unstructured.Unstructured{Object: map[string]interface{}{"spec": map[string]interface{}{}}}
+	previous := map[string]interface{}{"revision": "v2"}
+
+	testCases := []struct {
+		name            string
+		markUnsafe      bool
+		previousOutputs map[string]interface{}
+		expected        interface{}
+	}{
+		{
+			name: "outputs are not kept",
+		},
+		{
+			name:            "previous outputs",
+			previousOutputs: previous,
+			expected:        previous,
+		},
+		{
+			name:            "previous outputs marked unsafe",
+			markUnsafe:      true,
+			previousOutputs: previous,
+			expected: map[string]interface{}{
+				"revision": map[string]interface{}{"__ansible_unsafe": "v2"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			testRunner := runner{GVK: gvk, markUnsafe: tc.markUnsafe}
+			parameters := testRunner.makeParameters("1234", u, tc.previousOutputs, nil)
+			got, ok := parameters["ansible_operator_previous_outputs"]
+			if tc.expected == nil {
+				if ok {
+					t.Fatalf("Unexpected ansible_operator_previous_outputs: %v", got)
+				}
+				return
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Fatalf("Unexpected ansible_operator_previous_outputs:\n%#v\nexpected:\n%#v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestTaskTimes(t *testing.T) {
+	created := time.Date(2026, 10, 18, 10, 0, 5, 0, time.UTC)
+	testCases := []struct {
+		name          string
+		eventData     map[string]interface{}
+		expectedStart time.Time
+		expectedEnd   time.Time
+	}{
+		{
+			name: "start and end",
+			eventData: map[string]interface{}{
+				"start":    "2026-10-18T10:00:01.500000",
+				"end":      "2026-10-18T10:00:03",
+				"duration": 1.5,
+			},
+			expectedStart: time.Date(2026, 10, 18, 10, 0, 1, 500000000, time.UTC),
+			expectedEnd:   time.Date(2026, 10, 18, 10, 0, 3, 0, time.UTC),
+		},
+		{
+			name:          "duration only",
+			eventData:     map[string]interface{}{"duration": 2.0},
+			expectedStart: created.Add(-2 * time.Second),
+			expectedEnd:   created,
+		},
+		{
+			name:          "no times",
+			eventData:     map[string]interface{}{},
+			expectedStart: created,
+			expectedEnd:   created,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			event := eventapi.JobEvent{Created: eventapi.EventTime{Time: created}, EventData: tc.eventData}
+			start, end := taskTimes(event)
+			if !start.Equal(tc.expectedStart) || !end.Equal(tc.expectedEnd) {
+				t.Fatalf("Unexpected times %v - %v, expected %v - %v", start, end, tc.expectedStart, tc.expectedEnd)
+			}
+		})
+	}
+}
+
+func TestCloseEventServer(t *testing.T) {
+	server, err := sharedEventServer()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := CloseEventServer(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := os.Stat(server.SocketPath); !os.IsNotExist(err) {
+		t.Fatalf("Expected the socket %s to be removed: %v", server.SocketPath, err)
+	}
+	// closing it again is a no-op.
+	if err := CloseEventServer(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
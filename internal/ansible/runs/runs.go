@@ -0,0 +1,259 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package runs keeps track of the ansible runs in flight and of the most
+// recent ones, with their events and stdout, to introspect them live.
+package runs
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+const (
+	// DefaultKeep - the number of finished runs kept by default.
+	DefaultKeep = 20
+
+	// maxEvents is the number of events kept for each run, beyond which the
+	// events are no longer kept, to bound the memory used by long runs.
+	maxEvents = 10000
+
+	// maxStdout is the number of bytes of stdout kept for each run, the
+	// last ones, to bound the memory used by long runs.
+	maxStdout = 1 << 20
+)
+
+// Tracker - keeps track of the runs in flight, and of the runs finished most
+// recently. A nil Tracker tracks nothing.
+type Tracker struct {
+	mutex    sync.Mutex
+	runs     map[string]*Run
+	finished []string
+	keep     int
+}
+
+// NewTracker - creates a tracker keeping the keep runs finished most recently.
+func NewTracker(keep int) *Tracker {
+	return &Tracker{runs: map[string]*Run{}, keep: keep}
+}
+
+// Start - starts tracking the run of ident for the custom resource u.
+func (t *Tracker) Start(ident string, u *unstructured.Unstructured) *Run {
+	if t == nil {
+		return nil
+	}
+	r := &Run{
+		tracker:    t,
+		ident:      ident,
+		apiVersion: u.GetAPIVersion(),
+		kind:       u.GetKind(),
+		namespace:  u.GetNamespace(),
+		name:       u.GetName(),
+		startTime:  time.Now(),
+		changed:    make(chan struct{}),
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.runs[ident] = r
+	return r
+}
+
+// Get - the run of ident, if it is in flight or was kept.
+func (t *Tracker) Get(ident string) (*Run, bool) {
+	if t == nil {
+		return nil, false
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	r, ok := t.runs[ident]
+	return r, ok
+}
+
+// List - the runs in flight and kept, the most recent first.
+func (t *Tracker) List() []Info {
+	if t == nil {
+		return nil
+	}
+	t.mutex.Lock()
+	runs := make([]*Run, 0, len(t.runs))
+	for _, r := range t.runs {
+		runs = append(runs, r)
+	}
+	t.mutex.Unlock()
+
+	infos := make([]Info, 0, len(runs))
+	for _, r := range runs {
+		infos = append(infos, r.Info())
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].StartTime.After(infos[j].StartTime)
+	})
+	return infos
+}
+
+// finish moves a run to the finished runs, forgetting the oldest ones.
+func (t *Tracker) finish(ident string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.finished = append(t.finished, ident)
+	for len(t.finished) > t.keep {
+		delete(t.runs, t.finished[0])
+		t.finished = t.finished[1:]
+	}
+}
+
+// Info - a summary of a run.
+type Info struct {
+	Ident       string     `json:"ident"`
+	APIVersion  string     `json:"apiVersion"`
+	Kind        string     `json:"kind"`
+	Namespace   string     `json:"namespace,omitempty"`
+	Name        string     `json:"name"`
+	Running     bool       `json:"running"`
+	CurrentTask string     `json:"currentTask,omitempty"`
+	StartTime   time.Time  `json:"startTime"`
+	EndTime     *time.Time `json:"endTime,omitempty"`
+	// Elapsed is how long the run has been running, or ran, in seconds.
+	Elapsed float64 `json:"elapsed"`
+	// RC and Status are how ansible exited, once the run is finished. They
+	// are unset if the reconcile stopped following the run before then.
+	RC     *int   `json:"rc,omitempty"`
+	Status string `json:"status,omitempty"`
+}
+
+// Run - a tracked run. A nil Run tracks nothing.
+type Run struct {
+	tracker    *Tracker
+	ident      string
+	apiVersion string
+	kind       string
+	namespace  string
+	name       string
+	startTime  time.Time
+
+	mutex       sync.Mutex
+	endTime     time.Time
+	currentTask string
+	outcome     runner.Outcome
+	events      []eventapi.JobEvent
+	// stdout holds the last maxStdout bytes of stdout, among the last
+	// 2*maxStdout bytes written, so that it is only compacted once in a while.
+	stdout []byte
+	// changed is closed, and replaced, whenever an event is added or the
+	// run finishes, to wake up the followers of the run.
+	changed chan struct{}
+}
+
+// Event - records an event of the run.
+func (r *Run) Event(e eventapi.JobEvent) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if !r.endTime.IsZero() {
+		return
+	}
+	switch e.Event {
+	case eventapi.EventPlaybookOnTaskStart, eventapi.EventPlaybookOnHandlerTaskStart:
+		r.currentTask, _ = e.EventData["task"].(string)
+	case eventapi.EventPlaybookOnStats:
+		r.currentTask = ""
+	}
+	if len(r.events) < maxEvents {
+		r.events = append(r.events, e)
+	}
+	if e.StdOut != "" {
+		r.stdout = append(r.stdout, e.StdOut...)
+		r.stdout = append(r.stdout, '\n')
+		if len(r.stdout) > 2*maxStdout {
+			r.stdout = append(r.stdout[:0], r.stdout[len(r.stdout)-maxStdout:]...)
+		}
+	}
+	close(r.changed)
+	r.changed = make(chan struct{})
+}
+
+// Finish - records that the run finished with outcome. The outcome is empty
+// if the reconcile stopped following the run before ansible exited.
+func (r *Run) Finish(outcome runner.Outcome) {
+	if r == nil {
+		return
+	}
+	r.mutex.Lock()
+	if !r.endTime.IsZero() {
+		r.mutex.Unlock()
+		return
+	}
+	r.endTime = time.Now()
+	r.currentTask = ""
+	r.outcome = outcome
+	close(r.changed)
+	r.mutex.Unlock()
+	r.tracker.finish(r.ident)
+}
+
+// Info - a summary of the run.
+func (r *Run) Info() Info {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	info := Info{
+		Ident:       r.ident,
+		APIVersion:  r.apiVersion,
+		Kind:        r.kind,
+		Namespace:   r.namespace,
+		Name:        r.name,
+		Running:     r.endTime.IsZero(),
+		CurrentTask: r.currentTask,
+		StartTime:   r.startTime,
+		Elapsed:     time.Since(r.startTime).Seconds(),
+	}
+	if !info.Running {
+		endTime := r.endTime
+		info.EndTime = &endTime
+		info.Elapsed = r.endTime.Sub(r.startTime).Seconds()
+		if r.outcome.Status != "" {
+			rc := r.outcome.RC
+			info.RC = &rc
+			info.Status = string(r.outcome.Status)
+		}
+	}
+	return info
+}
+
+// EventsSince - the events of the run after the first n, whether the run is
+// finished, and a channel closed when there is more to follow.
+func (r *Run) EventsSince(n int) ([]eventapi.JobEvent, bool, <-chan struct{}) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var events []eventapi.JobEvent
+	if n < len(r.events) {
+		events = append(events, r.events[n:]...)
+	}
+	return events, !r.endTime.IsZero(), r.changed
+}
+
+// Stdout - the stdout of the run so far, truncated to its last maxStdout
+// bytes.
+func (r *Run) Stdout() string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return string(r.stdout[max(0, len(r.stdout)-maxStdout):])
+}
@@ -0,0 +1,108 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runs
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/eventapi"
+)
+
+func newCR(name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("cache.example.com/v1alpha1")
+	u.SetKind("Memcached")
+	u.SetNamespace("default")
+	u.SetName(name)
+	return u
+}
+
+func TestTracker(t *testing.T) {
+	tracker := NewTracker(1)
+	first := tracker.Start("1", newCR("first"))
+	first.Event(eventapi.JobEvent{Event: eventapi.EventPlaybookOnTaskStart, StdOut: "TASK [probe]",
+		EventData: map[string]interface{}{"task": "probe"}})
+
+	info := first.Info()
+	if !info.Running || info.CurrentTask != "probe" || info.RC != nil || info.Kind != "Memcached" {
+		t.Fatalf("Unexpected info of a run in flight %+v", info)
+	}
+	events, finished, changed := first.EventsSince(0)
+	if len(events) != 1 || finished {
+		t.Fatalf("Unexpected events %v, finished %v", events, finished)
+	}
+
+	first.Event(eventapi.JobEvent{Event: eventapi.EventRunnerOnOk, StdOut: "ok: [localhost]"})
+	select {
+	case <-changed:
+	default:
+		t.Fatalf("Expected the followers to be woken up by an event")
+	}
+	first.Finish(runner.Outcome{RC: 0, Status: runner.RunSuccessful})
+	info = first.Info()
+	if info.Running || info.CurrentTask != "" || info.RC == nil || *info.RC != 0 || info.Status != "successful" {
+		t.Fatalf("Unexpected info of a finished run %+v", info)
+	}
+	if stdout := first.Stdout(); stdout != "TASK [probe]\nok: [localhost]\n" {
+		t.Fatalf("Unexpected stdout %q", stdout)
+	}
+	if events, finished, _ := first.EventsSince(1); len(events) != 1 || !finished {
+		t.Fatalf("Unexpected events %v, finished %v", events, finished)
+	}
+
+	// only the most recent finished run is kept.
+	second := tracker.Start("2", newCR("second"))
+	if list := tracker.List(); len(list) != 2 || list[0].Ident != "2" || list[1].Ident != "1" {
+		t.Fatalf("Unexpected runs %+v", list)
+	}
+	second.Finish(runner.Outcome{})
+	if _, ok := tracker.Get("1"); ok {
+		t.Fatalf("Expected the oldest finished run to be forgotten")
+	}
+	if info := tracker.List(); len(info) != 1 || info[0].RC != nil || info[0].Status != "" {
+		t.Fatalf("Unexpected runs %+v", info)
+	}
+}
+
+func TestStdoutTruncated(t *testing.T) {
+	run := NewTracker(1).Start("1", newCR("example"))
+	line := strings.Repeat("x", 1023)
+	for i := 0; i < 3*maxStdout/1024; i++ {
+		run.Event(eventapi.JobEvent{Event: eventapi.EventRunnerOnOk, StdOut: line})
+	}
+	run.Event(eventapi.JobEvent{Event: eventapi.EventPlaybookOnStats, StdOut: "PLAY RECAP"})
+
+	stdout := run.Stdout()
+	if len(stdout) != maxStdout {
+		t.Fatalf("Unexpected stdout length %d", len(stdout))
+	}
+	if !strings.HasSuffix(stdout, line+"\nPLAY RECAP\n") {
+		t.Fatalf("Unexpected end of stdout %q", stdout[len(stdout)-100:])
+	}
+}
+
+func TestNilTracker(t *testing.T) {
+	var tracker *Tracker
+	run := tracker.Start("1", newCR("example"))
+	run.Event(eventapi.JobEvent{Event: eventapi.EventRunnerOnOk})
+	run.Finish(runner.Outcome{})
+	if list := tracker.List(); len(list) != 0 {
+		t.Fatalf("Unexpected runs %+v", list)
+	}
+}
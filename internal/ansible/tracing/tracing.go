@@ -0,0 +1,127 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing traces reconciles, the ansible runs they start, the tasks
+// of those runs and the API calls the tasks make through the proxy, with
+// OpenTelemetry.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// tracerName is the name of the tracer of the spans of the operator.
+const tracerName = "github.com/operator-framework/ansible-operator-plugins/internal/ansible"
+
+var log = logf.Log.WithName("tracing")
+
+// propagator carries the trace context as a W3C traceparent.
+var propagator = propagation.TraceContext{}
+
+// Options - where the spans are exported. Spans are not recorded if neither
+// an endpoint nor a file is set.
+type Options struct {
+	// Endpoint is the URL of an OTLP collector accepting gRPC, e.g.
+	// http://localhost:4317. TLS is used unless the scheme is http.
+	Endpoint string
+	// File is the path of a file the spans are written to, as JSON objects.
+	File string
+	// ServiceName is the name of the service the spans belong to. Defaults
+	// to "ansible-operator".
+	ServiceName string
+}
+
+// Setup - installs the tracer provider exporting spans as configured by
+// options. The returned function flushes the spans not yet exported and stops
+// exporting.
+func Setup(ctx context.Context, options Options) (func(context.Context) error, error) {
+	if options.Endpoint == "" && options.File == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+	if options.ServiceName == "" {
+		options.ServiceName = "ansible-operator"
+	}
+
+	var providerOptions []sdktrace.TracerProviderOption
+	var file *os.File
+	if options.Endpoint != "" {
+		exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithEndpointURL(options.Endpoint))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the OTLP exporter: %w", err)
+		}
+		providerOptions = append(providerOptions, sdktrace.WithBatcher(exporter))
+	}
+	if options.File != "" {
+		var err error
+		file, err = os.OpenFile(options.File, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open the trace file: %w", err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to create the file exporter: %w", err)
+		}
+		providerOptions = append(providerOptions, sdktrace.WithBatcher(exporter))
+	}
+	res, err := resource.Merge(resource.Default(),
+		resource.NewSchemaless(attribute.String("service.name", options.ServiceName)))
+	if err != nil {
+		return nil, err
+	}
+	provider := sdktrace.NewTracerProvider(append(providerOptions, sdktrace.WithResource(res))...)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagator)
+	log.Info("Tracing enabled", "endpoint", options.Endpoint, "file", options.File)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if file != nil {
+			err = errors.Join(err, file.Close())
+		}
+		return err
+	}, nil
+}
+
+// Tracer - the tracer of the spans of the operator.
+func Tracer() trace.Tracer {
+	return otel.Tracer(tracerName)
+}
+
+// TraceParent - the W3C traceparent of the span in ctx, or "" if there is
+// none to propagate.
+func TraceParent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	return carrier.Get("traceparent")
+}
+
+// ContextWithTraceParent - returns ctx with the remote span of the W3C
+// traceparent, if valid.
+func ContextWithTraceParent(ctx context.Context, traceParent string) context.Context {
+	return propagator.Extract(ctx, propagation.MapCarrier{"traceparent": traceParent})
+}
@@ -0,0 +1,100 @@
+// Copyright 2026 The Operator-SDK Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// exportedSpan holds the fields of a span written by the file exporter that
+// the tests check.
+type exportedSpan struct {
+	Name        string
+	SpanContext struct {
+		TraceID string
+		SpanID  string
+	}
+	Parent struct {
+		SpanID string
+	}
+}
+
+func TestFileExport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.json")
+	shutdown, err := Setup(context.Background(), Options{File: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, span := Tracer().Start(context.Background(), "Reconcile")
+	traceParent := TraceParent(ctx)
+	if traceParent == "" {
+		t.Fatalf("Expected a traceparent for a recorded span")
+	}
+
+	// a proxied request made with the kubeconfig of the run.
+	handler := Handler(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces/default/configmaps/example", nil)
+	req = req.WithContext(ContextWithTraceParent(req.Context(), traceParent))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	span.End()
+
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	spans := map[string]exportedSpan{}
+	for decoder := json.NewDecoder(f); ; {
+		var s exportedSpan
+		if err := decoder.Decode(&s); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		spans[s.Name] = s
+	}
+	reconcile, proxied := spans["Reconcile"], spans["proxy GET"]
+	if len(spans) != 2 || proxied.SpanContext.TraceID != reconcile.SpanContext.TraceID ||
+		proxied.Parent.SpanID != reconcile.SpanContext.SpanID {
+		t.Fatalf("Unexpected spans %+v", spans)
+	}
+}
+
+func TestSetupDisabled(t *testing.T) {
+	shutdown, err := Setup(context.Background(), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if tp := ContextWithTraceParent(context.Background(), "unused"); TraceParent(tp) != "" {
+		t.Fatalf("Expected no trace context from an invalid traceparent")
+	}
+}
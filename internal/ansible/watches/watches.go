@@ -32,6 +32,9 @@ import (
 	yaml "sigs.k8s.io/yaml"
 
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/flags"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/impersonation"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/paramconv"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/redact"
 )
 
 var log = logf.Log.WithName("watches")
@@ -54,6 +57,12 @@ type Watch struct {
 	WatchAnnotationsChanges     bool                      `yaml:"watchAnnotationsChanges"`
 	MarkUnsafe                  bool                      `yaml:"markUnsafe"`
 	Selector                    metav1.LabelSelector      `yaml:"selector"`
+	RedactPaths                 []string                  `yaml:"redactPaths"`
+	ParameterConversion         paramconv.Rules           `yaml:"parameterConversion"`
+	OutputsStore                string                    `yaml:"outputsStore"`
+	ImpersonateServiceAccount   string                    `yaml:"impersonateServiceAccount"`
+	Prune                       bool                      `yaml:"prune"`
+	PruneKinds                  []schema.GroupKind        `yaml:"pruneKinds"`
 
 	// Not configurable via watches.yaml
 	MaxConcurrentReconciles int `yaml:"-"`
@@ -68,6 +77,15 @@ type Finalizer struct {
 	Vars     map[string]interface{} `yaml:"vars"`
 }
 
+const (
+	// OutputsStoreStatus - keeps the outputs of a run in the status.outputs
+	// field of the custom resource.
+	OutputsStoreStatus = "status"
+	// OutputsStoreConfigMap - keeps the outputs of a run in a ConfigMap owned
+	// by the custom resource.
+	OutputsStoreConfigMap = "configMap"
+)
+
 // Default values for optional fields on Watch
 var (
 	blacklistDefault                   = []schema.GroupVersionKind{}
@@ -79,6 +97,7 @@ var (
 	snakeCaseParametersDefault         = true
 	watchAnnotationsChangesDefault     = false
 	markUnsafeDefault                  = false
+	pruneDefault                       = false
 	selectorDefault                    = metav1.LabelSelector{}
 
 	// these are overridden by cmdline flags
@@ -105,6 +124,12 @@ type alias struct {
 	Blacklist                   []schema.GroupVersionKind `yaml:"blacklist,omitempty"`
 	Finalizer                   *Finalizer                `yaml:"finalizer"`
 	Selector                    metav1.LabelSelector      `yaml:"selector"`
+	RedactPaths                 []string                  `yaml:"redactPaths,omitempty"`
+	ParameterConversion         paramconv.Rules           `yaml:"parameterConversion"`
+	OutputsStore                string                    `yaml:"outputsStore,omitempty"`
+	ImpersonateServiceAccount   string                    `yaml:"impersonateServiceAccount,omitempty"`
+	Prune                       *bool                     `yaml:"prune,omitempty"`
+	PruneKinds                  []schema.GroupKind        `yaml:"pruneKinds,omitempty"`
 }
 
 // buildWatch will build Watch based on the values parsed from alias
@@ -145,6 +170,10 @@ func (w *Watch) setValuesFromAlias(tmp alias) error {
 		tmp.MarkUnsafe = &markUnsafeDefault
 	}
 
+	if tmp.Prune == nil {
+		tmp.Prune = &pruneDefault
+	}
+
 	gvk := schema.GroupVersionKind{
 		Group:   tmp.Group,
 		Version: tmp.Version,
@@ -179,6 +208,12 @@ func (w *Watch) setValuesFromAlias(tmp alias) error {
 	}
 	w.addRolePlaybookPaths(wd)
 	w.Selector = tmp.Selector
+	w.RedactPaths = tmp.RedactPaths
+	w.ParameterConversion = tmp.ParameterConversion
+	w.OutputsStore = tmp.OutputsStore
+	w.ImpersonateServiceAccount = tmp.ImpersonateServiceAccount
+	w.Prune = *tmp.Prune
+	w.PruneKinds = tmp.PruneKinds
 
 	return nil
 }
@@ -261,6 +296,11 @@ func getPossibleRolePaths(rootDir, path string) []string {
 // A Watch is considered valid if it:
 // - Specifies a valid path to a Role||Playbook
 // - If a Finalizer is non-nil, it must have a name + valid path to a Role||Playbook or Vars
+// - Specifies only valid JSON paths in RedactPaths
+// - Specifies valid ParameterConversion rules
+// - Specifies a known OutputsStore, if any
+// - Specifies a valid ImpersonateServiceAccount template, if any
+// - Specifies the PruneKinds that may be pruned, with a kind each, if Prune is set
 func (w *Watch) Validate() error {
 	err := verifyAnsiblePath(w.Playbook, w.Role)
 	if err != nil {
@@ -268,6 +308,43 @@ func (w *Watch) Validate() error {
 		return err
 	}
 
+	if _, err := redact.New(w.RedactPaths); err != nil {
+		log.Error(err, fmt.Sprintf("Invalid redact paths for GVK: %v", w.GroupVersionKind.String()))
+		return err
+	}
+
+	if err := w.ParameterConversion.Validate(); err != nil {
+		log.Error(err, fmt.Sprintf("Invalid parameter conversion for GVK: %v", w.GroupVersionKind.String()))
+		return err
+	}
+
+	switch w.OutputsStore {
+	case "", OutputsStoreStatus, OutputsStoreConfigMap:
+	default:
+		err = fmt.Errorf("outputsStore must be %q or %q, got %q", OutputsStoreStatus, OutputsStoreConfigMap,
+			w.OutputsStore)
+		log.Error(err, fmt.Sprintf("Invalid outputs store for GVK: %v", w.GroupVersionKind.String()))
+		return err
+	}
+
+	if _, err := impersonation.New(w.ImpersonateServiceAccount); err != nil {
+		log.Error(err, fmt.Sprintf("Invalid service account to impersonate for GVK: %v", w.GroupVersionKind.String()))
+		return err
+	}
+
+	if w.Prune && len(w.PruneKinds) == 0 {
+		err = errors.New("prune requires the pruneKinds that may be pruned")
+		log.Error(err, fmt.Sprintf("Invalid pruning for GVK: %v", w.GroupVersionKind.String()))
+		return err
+	}
+	for _, gk := range w.PruneKinds {
+		if gk.Kind == "" {
+			err = fmt.Errorf("pruneKinds must have a kind, got %q", gk.String())
+			log.Error(err, fmt.Sprintf("Invalid pruning for GVK: %v", w.GroupVersionKind.String()))
+			return err
+		}
+	}
+
 	if w.Finalizer != nil {
 		if w.Finalizer.Name == "" {
 			err = fmt.Errorf("finalizer must have name")
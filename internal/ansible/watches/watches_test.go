@@ -27,6 +27,8 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/paramconv"
 )
 
 func TestNew(t *testing.T) {
@@ -382,6 +384,62 @@ func TestLoad(t *testing.T) { //nolint:gocyclo
 			},
 			ManageStatus: true,
 		},
+		{
+			GroupVersionKind: schema.GroupVersionKind{
+				Version: "v1alpha1",
+				Group:   "app.example.com",
+				Kind:    "RedactPathsTest",
+			},
+			Role:         validTemplate.ValidRole,
+			ManageStatus: true,
+			RedactPaths:  []string{"spec.password", "spec.users[*].token"},
+		},
+		Watch{
+			GroupVersionKind: schema.GroupVersionKind{
+				Version: "v1alpha1",
+				Group:   "app.example.com",
+				Kind:    "ParameterConversionTest",
+			},
+			Role:         validTemplate.ValidRole,
+			ManageStatus: true,
+			ParameterConversion: paramconv.Rules{
+				WordMappings:     map[string]string{"ssl": "SSL", "ca": "CA"},
+				PreserveKeys:     []string{"nodeSelector"},
+				MaxDepth:         2,
+				LowerLeadingWord: true,
+			},
+		},
+		Watch{
+			GroupVersionKind: schema.GroupVersionKind{
+				Version: "v1alpha1",
+				Group:   "app.example.com",
+				Kind:    "OutputsStoreTest",
+			},
+			Role:         validTemplate.ValidRole,
+			ManageStatus: true,
+			OutputsStore: OutputsStoreConfigMap,
+		},
+		Watch{
+			GroupVersionKind: schema.GroupVersionKind{
+				Version: "v1alpha1",
+				Group:   "app.example.com",
+				Kind:    "ImpersonationTest",
+			},
+			Role:                      validTemplate.ValidRole,
+			ManageStatus:              true,
+			ImpersonateServiceAccount: "ops/tenant-runner",
+		},
+		Watch{
+			GroupVersionKind: schema.GroupVersionKind{
+				Version: "v1alpha1",
+				Group:   "app.example.com",
+				Kind:    "PruneTest",
+			},
+			Role:         validTemplate.ValidRole,
+			ManageStatus: true,
+			Prune:        true,
+			PruneKinds:   []schema.GroupKind{{Kind: "ConfigMap"}, {Group: "apps", Kind: "Deployment"}},
+		},
 	}
 
 	testCases := []struct {
@@ -459,6 +517,31 @@ func TestLoad(t *testing.T) { //nolint:gocyclo
 			path:        "testdata/invalid_status.yaml",
 			shouldError: true,
 		},
+		{
+			name:        "error invalid redact path",
+			path:        "testdata/invalid_redact_path.yaml",
+			shouldError: true,
+		},
+		{
+			name:        "error invalid parameter conversion",
+			path:        "testdata/invalid_parameter_conversion.yaml",
+			shouldError: true,
+		},
+		{
+			name:        "error invalid outputs store",
+			path:        "testdata/invalid_outputs_store.yaml",
+			shouldError: true,
+		},
+		{
+			name:        "error invalid service account to impersonate",
+			path:        "testdata/invalid_impersonate_service_account.yaml",
+			shouldError: true,
+		},
+		{
+			name:        "error prune without kinds",
+			path:        "testdata/invalid_prune.yaml",
+			shouldError: true,
+		},
 		{
 			name:        "if collection env var is not set and collection is not installed to the default locations, fail",
 			path:        "testdata/invalid_collection.yaml",
@@ -561,6 +644,32 @@ func TestLoad(t *testing.T) { //nolint:gocyclo
 					}
 				}
 
+				if !reflect.DeepEqual(gotWatch.RedactPaths, expectedWatch.RedactPaths) {
+					t.Fatalf("Incorrect redact paths GVK %s:\n\tgot %v\n\texpected %v", gvk,
+						gotWatch.RedactPaths, expectedWatch.RedactPaths)
+				}
+
+				if !reflect.DeepEqual(gotWatch.ParameterConversion, expectedWatch.ParameterConversion) {
+					t.Fatalf("Incorrect parameter conversion GVK %s:\n\tgot %v\n\texpected %v", gvk,
+						gotWatch.ParameterConversion, expectedWatch.ParameterConversion)
+				}
+
+				if gotWatch.OutputsStore != expectedWatch.OutputsStore {
+					t.Fatalf("Incorrect outputs store GVK %s:\n\tgot %s\n\texpected %s", gvk,
+						gotWatch.OutputsStore, expectedWatch.OutputsStore)
+				}
+
+				if gotWatch.ImpersonateServiceAccount != expectedWatch.ImpersonateServiceAccount {
+					t.Fatalf("Incorrect service account to impersonate GVK %s:\n\tgot %s\n\texpected %s", gvk,
+						gotWatch.ImpersonateServiceAccount, expectedWatch.ImpersonateServiceAccount)
+				}
+
+				if gotWatch.Prune != expectedWatch.Prune ||
+					!reflect.DeepEqual(gotWatch.PruneKinds, expectedWatch.PruneKinds) {
+					t.Fatalf("Incorrect pruning GVK %s:\n\tgot %v %v\n\texpected %v %v", gvk,
+						gotWatch.Prune, gotWatch.PruneKinds, expectedWatch.Prune, expectedWatch.PruneKinds)
+				}
+
 				if !reflect.DeepEqual(gotWatch.Selector, expectedWatch.Selector) {
 					t.Fatalf("Incorrect selector GVK %s:\n\tgot %s\n\texpected %s", gvk,
 						gotWatch.Selector, expectedWatch.Selector)
@@ -15,6 +15,7 @@
 package run
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"fmt"
@@ -27,6 +28,8 @@ import (
 	"github.com/go-logr/logr"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
@@ -42,10 +45,22 @@ import (
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/controller"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/events"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/flags"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/impersonation"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/metrics"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/outputs"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/paramconv"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/audit"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/controllermap"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/credentials"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/inventory"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/kubeconfig"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/proxy/writes"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/redact"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runner/replay"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/runs"
+	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/tracing"
 	"github.com/operator-framework/ansible-operator-plugins/internal/ansible/watches"
 	"github.com/operator-framework/ansible-operator-plugins/internal/util/k8sutil"
 	sdkVersion "github.com/operator-framework/ansible-operator-plugins/internal/version"
@@ -136,6 +151,11 @@ func run(cmd *cobra.Command, f *flags.Flags) {
 		os.Exit(1)
 	}
 
+	if f.AnsibleRecordDir != "" && f.AnsibleReplayDir != "" {
+		log.Error(errors.New("only one of --ansible-record-dir and --ansible-replay-dir may be set"), "invalid flags usage")
+		os.Exit(1)
+	}
+
 	// Set default manager options
 	// TODO: probably should expose the host & port as an environment variables
 	options = f.ToManagerOptions(options)
@@ -145,12 +165,41 @@ func run(cmd *cobra.Command, f *flags.Flags) {
 
 	configureWatchNamespaces(&options, log)
 
+	// the proxy removes the informers of the dependent resources once
+	// unused, but those the controllers or the client of the manager use.
+	newCache := options.NewCache
+	if newCache == nil {
+		newCache = cache.New
+	}
+	options.NewCache = func(config *rest.Config, opts cache.Options) (cache.Cache, error) {
+		c, err := newCache(config, opts)
+		if err != nil {
+			return nil, err
+		}
+		return proxy.NewSharedCache(c, opts.Scheme), nil
+	}
+
 	err = setAnsibleEnvVars(f)
 	if err != nil {
 		log.Error(err, "Failed to set environment variable.")
 		os.Exit(1)
 	}
 
+	proxyURL, proxyEnv, shimDir, err := getProxyRunEnv(f)
+	if err != nil {
+		log.Error(err, "Failed to set up the proxy transport of the runs.")
+		os.Exit(1)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		Endpoint: f.TracingEndpoint,
+		File:     f.TracingFile,
+	})
+	if err != nil {
+		log.Error(err, "Failed to set up tracing.")
+		os.Exit(1)
+	}
+
 	// Create a new manager to provide shared dependencies and start components
 	mgr, err := manager.New(cfg, options)
 	if err != nil {
@@ -167,6 +216,44 @@ func run(cmd *cobra.Command, f *flags.Flags) {
 		os.Exit(1)
 	}
 
+	eventDispatch := events.DispatchOptions{
+		BufferSize: f.EventHandlerBuffer,
+		Overflow:   events.OverflowPolicy(f.EventHandlerOverflow),
+	}
+	if err := eventDispatch.Validate(); err != nil {
+		log.Error(err, "Invalid event handler options.")
+		os.Exit(1)
+	}
+
+	var eventHandlers []events.EventHandler
+	if f.EventWebhookURL != "" {
+		webhook, err := newEventWebhook(f)
+		if err != nil {
+			log.Error(err, "Failed to create the event webhook.")
+			os.Exit(1)
+		}
+		if err := mgr.Add(webhook); err != nil {
+			log.Error(err, "Failed to add the event webhook to the manager.")
+			os.Exit(1)
+		}
+		eventHandlers = append(eventHandlers, webhook)
+	}
+
+	trackedRuns := runs.NewTracker(runs.DefaultKeep)
+	proxyCredentials := credentials.NewStore(credentials.DefaultTTL)
+	proxyInventory := inventory.NewRecorder()
+	var proxyWrites *writes.Tracker
+	if f.ProxyReadYourWritesTimeout > 0 {
+		proxyWrites = writes.NewTracker()
+	}
+	runsOptions, err := getRunsOptions(f)
+	if err != nil {
+		log.Error(err, "Invalid runs endpoints options.")
+		os.Exit(1)
+	}
+	runsOptions.Runs = trackedRuns
+	runOutputs := outputs.NewTracker()
+
 	cMap := controllermap.NewControllerMap()
 	watches, err := watches.Load(f.WatchesFile, f.MaxConcurrentReconciles, f.AnsibleVerbosity)
 	if err != nil {
@@ -181,12 +268,40 @@ func run(cmd *cobra.Command, f *flags.Flags) {
 			reconcilePeriod = w.ReconcilePeriod.Duration
 		}
 
-		runner, err := runner.New(w, f.AnsibleArgs)
+		runner, err := runner.New(w, f.AnsibleArgs, runner.Executor(f.AnsibleExecutor), proxyEnv)
 		if err != nil {
 			log.Error(err, "Failed to create runner")
 			os.Exit(1)
 		}
+		if f.AnsibleRecordDir != "" {
+			runner = &replay.Recorder{Runner: runner, Dir: f.AnsibleRecordDir}
+		} else if f.AnsibleReplayDir != "" {
+			runner = &replay.DirRunner{Runner: runner, Dir: f.AnsibleReplayDir}
+		}
+
+		redactor, err := redact.New(w.RedactPaths)
+		if err != nil {
+			log.Error(err, "Failed to create redactor")
+			os.Exit(1)
+		}
 
+		impersonate, err := impersonation.New(w.ImpersonateServiceAccount)
+		if err != nil {
+			log.Error(err, "Failed to parse the service account to impersonate")
+			os.Exit(1)
+		}
+
+		var pruneKinds []schema.GroupKind
+		if w.Prune {
+			pruneKinds = w.PruneKinds
+		}
+
+		// the runs set the outputs kept in the status with the snake_case
+		// keys of their parameters.
+		var statusConverter *paramconv.Converter
+		if w.SnakeCaseParameters {
+			statusConverter = paramconv.NewConverter(w.ParameterConversion)
+		}
 		ctr := controller.Add(mgr, controller.Options{
 			GVK:                     w.GroupVersionKind,
 			Runner:                  runner,
@@ -196,7 +311,21 @@ func run(cmd *cobra.Command, f *flags.Flags) {
 			ReconcilePeriod:         reconcilePeriod,
 			Selector:                w.Selector,
 			LoggingLevel:            getAnsibleEventsToLog(f),
+			LogFormat:               getAnsibleLogFormat(f),
+			EventHandlers:           eventHandlers,
+			EventDispatch:           eventDispatch,
 			WatchAnnotationsChanges: w.WatchAnnotationsChanges,
+			OutputsStore:            w.OutputsStore,
+			Outputs:                 runOutputs,
+			StatusConverter:         statusConverter,
+			Runs:                    trackedRuns,
+			Credentials:             proxyCredentials,
+			ProxyURL:                proxyURL,
+			Impersonate:             impersonate,
+			Inventory:               proxyInventory,
+			PruneKinds:              pruneKinds,
+			ControllerMap:           cMap,
+			Writes:                  proxyWrites,
 		})
 		if ctr == nil {
 			log.Error(fmt.Errorf("failed to add controller for GVK %v", w.GroupVersionKind.String()), "")
@@ -208,6 +337,7 @@ func run(cmd *cobra.Command, f *flags.Flags) {
 			WatchClusterScopedResources: w.WatchClusterScopedResources,
 			OwnerWatchMap:               controllermap.NewWatchMap(),
 			AnnotationWatchMap:          controllermap.NewWatchMap(),
+			Redactor:                    redactor,
 		}, w.Blacklist)
 	}
 
@@ -217,19 +347,37 @@ func run(cmd *cobra.Command, f *flags.Flags) {
 		log.Error(err, "Failed to add Healthz check.")
 	}
 
+	var auditSink *audit.Sink
+	if f.ProxyAuditLog != "" {
+		auditSink, err = audit.NewSink(f.ProxyAuditLog, int64(f.ProxyAuditLogMaxSize)*1024*1024,
+			f.ProxyAuditLogMaxBackups)
+		if err != nil {
+			log.Error(err, "Failed to open the proxy audit log.")
+			os.Exit(1)
+		}
+	}
+
 	done := make(chan error)
 
 	// start the proxy
 	err = proxy.Run(done, proxy.Options{
-		Address:           "localhost",
-		Port:              f.ProxyPort,
-		KubeConfig:        mgr.GetConfig(),
-		Scheme:            mgr.GetScheme(),
-		Cache:             mgr.GetCache(),
-		RESTMapper:        mgr.GetRESTMapper(),
-		ControllerMap:     cMap,
-		OwnerInjection:    f.InjectOwnerRef,
-		WatchedNamespaces: options.Cache.DefaultNamespaces,
+		Address:                   "localhost",
+		Port:                      f.ProxyPort,
+		KubeConfig:                mgr.GetConfig(),
+		Scheme:                    mgr.GetScheme(),
+		Cache:                     mgr.GetCache(),
+		RESTMapper:                mgr.GetRESTMapper(),
+		ControllerMap:             cMap,
+		OwnerInjection:            f.InjectOwnerRef,
+		WatchedNamespaces:         options.Cache.DefaultNamespaces,
+		Credentials:               proxyCredentials,
+		SocketPath:                f.ProxySocket,
+		Audit:                     auditSink,
+		AuditBodies:               f.ProxyAuditLogBodies,
+		Inventory:                 proxyInventory,
+		Writes:                    proxyWrites,
+		ReadYourWritesTimeout:     f.ProxyReadYourWritesTimeout,
+		DependentWatchGracePeriod: f.DependentWatchGracePeriod,
 	})
 	if err != nil {
 		log.Error(err, "Error starting proxy.")
@@ -238,11 +386,18 @@ func run(cmd *cobra.Command, f *flags.Flags) {
 	// start the ansible-operator api server
 	go func() {
 		err = apiserver.Run(apiserver.Options{
-			Address: "localhost",
-			Port:    5050,
+			Address:     "localhost",
+			Port:        5050,
+			Outputs:     runOutputs,
+			Credentials: proxyCredentials,
 		})
 		done <- err
 	}()
+	if runsOptions.Address != "" {
+		go func() {
+			done <- apiserver.ServeRuns(runsOptions)
+		}()
+	}
 
 	// start the operator
 	go func() {
@@ -251,6 +406,22 @@ func run(cmd *cobra.Command, f *flags.Flags) {
 
 	// wait for either to finish
 	err = <-done
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		log.Error(err, "Failed to export the remaining traces.")
+	}
+	cancel()
+	if err := auditSink.Close(); err != nil {
+		log.Error(err, "Failed to close the proxy audit log.")
+	}
+	if err := runner.CloseEventServer(); err != nil {
+		log.Error(err, "Failed to close the event API.")
+	}
+	if shimDir != "" {
+		if err := os.RemoveAll(shimDir); err != nil {
+			log.Error(err, "Failed to remove the python socket shim.")
+		}
+	}
 	if err != nil {
 		log.Error(err, "Proxy or operator exited with error.")
 		os.Exit(1)
@@ -314,6 +485,57 @@ func getAnsibleEventsToLog(f *flags.Flags) events.LogLevel {
 	}
 }
 
+// getAnsibleLogFormat return the format ansible output is logged in, set in the flag
+func getAnsibleLogFormat(f *flags.Flags) events.LogFormat {
+	switch format := events.LogFormat(strings.ToLower(f.AnsibleLogFormat)); format {
+	case events.TextLogFormat, events.JSONLogFormat:
+		return format
+	default:
+		if f.AnsibleLogFormat != "" {
+			log.Error(fmt.Errorf("--ansible-log-format flag value '%s' not recognized. Must be one of: text, json", f.AnsibleLogFormat), "unrecognized log format")
+		}
+		return events.TextLogFormat // text is the default
+	}
+}
+
+// getRunsOptions returns the options of the /runs endpoints set in the flags
+func getRunsOptions(f *flags.Flags) (apiserver.RunsOptions, error) {
+	options := apiserver.RunsOptions{Address: f.RunsBindAddress}
+	if options.Address == "" {
+		return options, nil
+	}
+	if f.RunsTokenFile != "" {
+		token, err := os.ReadFile(f.RunsTokenFile)
+		if err != nil {
+			return apiserver.RunsOptions{}, fmt.Errorf("failed to read the runs token: %w", err)
+		}
+		options.Token = strings.TrimSpace(string(token))
+		if options.Token == "" {
+			return apiserver.RunsOptions{}, fmt.Errorf("runs token file %s is empty", f.RunsTokenFile)
+		}
+	}
+	return options, options.Validate()
+}
+
+// newEventWebhook creates the handler forwarding events to the webhook set in the flags
+func newEventWebhook(f *flags.Flags) (*events.WebhookHandler, error) {
+	options := events.WebhookOptions{
+		URL:           f.EventWebhookURL,
+		EventTypes:    f.EventWebhookEvents,
+		BatchSize:     f.EventWebhookBatchSize,
+		FlushInterval: f.EventWebhookFlushInterval,
+		MaxRetries:    f.EventWebhookRetries,
+	}
+	for _, arg := range f.EventWebhookGVKs {
+		gvk, _ := schema.ParseKindArg(arg)
+		if gvk == nil {
+			return nil, fmt.Errorf("--event-webhook-gvks value %q must be of the form Kind.version.group", arg)
+		}
+		options.GVKs = append(options.GVKs, *gvk)
+	}
+	return events.NewWebhookHandler(options)
+}
+
 // setAnsibleEnvVars will set environment variables based on CLI flags
 func setAnsibleEnvVars(f *flags.Flags) error {
 	if len(f.AnsibleRolesPath) > 0 {
@@ -334,6 +556,26 @@ func setAnsibleEnvVars(f *flags.Flags) error {
 	return nil
 }
 
+// getProxyRunEnv returns the URL of the proxy for the kubeconfig of the runs
+// and the environment variables the runs need to reach it. When the proxy
+// serves on a unix socket, the directory of the python module routing the runs
+// to it is also returned, to be removed on shutdown.
+func getProxyRunEnv(f *flags.Flags) (string, map[string]string, string, error) {
+	if f.ProxySocket == "" {
+		return fmt.Sprintf("http://localhost:%d", f.ProxyPort), nil, "", nil
+	}
+	dir, err := os.MkdirTemp("", "ansible-operator-python")
+	if err != nil {
+		return "", nil, "", err
+	}
+	env, err := kubeconfig.SocketEnv(dir, f.ProxySocket)
+	if err != nil {
+		return "", nil, "", errors.Join(err, os.RemoveAll(dir))
+	}
+	log.Info("Routing the runs to the proxy socket", "socket", f.ProxySocket, "PYTHONPATH", env["PYTHONPATH"])
+	return kubeconfig.SocketProxyURL, env, dir, nil
+}
+
 func configureWatchNamespaces(options *manager.Options, log logr.Logger) {
 	namespaces := splitNamespaces(os.Getenv(k8sutil.WatchNamespaceEnvVar))
 